@@ -0,0 +1,116 @@
+package lane
+
+import (
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+// BoundPriorityQueue is a priority queue with a user-defined capacity,
+// retaining the highest priority items pushed into it: once full, pushing
+// an item evicts the lowest priority one. It thus tracks the top k items
+// of a stream, k being its capacity.
+//
+// Under the hood, BoundPriorityQueue's implementation relies upon a
+// DoublePriorityQueue, whose operations it exposes. Pushing and popping
+// items from either end happens in *O(log n)* time complexity.
+//
+// Every operation on a BoundPriorityQueue is goroutine-safe.
+type BoundPriorityQueue[T any, P any] struct {
+	DoublePriorityQueue[T, P]
+
+	// capacity defines an upper bound limit for the BoundPriorityQueue's size.
+	capacity uint
+}
+
+// BoundPriorityQueue implements Capacitor.
+var _ Capacitor = (*BoundPriorityQueue[int, int])(nil)
+
+// NewBoundPriorityQueue produces a new BoundPriorityQueue instance with the
+// provided capacity, ordering priorities using the less function, which
+// reports whether `lhs` is lower than `rhs`.
+func NewBoundPriorityQueue[T any, P any](capacity uint, less func(lhs, rhs P) bool) *BoundPriorityQueue[T, P] {
+	return &BoundPriorityQueue[T, P]{
+		DoublePriorityQueue: *NewDoublePriorityQueue[T](less),
+		capacity:            capacity,
+	}
+}
+
+// NewOrderedBoundPriorityQueue produces a new BoundPriorityQueue instance
+// with the provided capacity, ordering priorities using their natural order.
+func NewOrderedBoundPriorityQueue[T any, P constraints.Ordered](capacity uint) *BoundPriorityQueue[T, P] {
+	return &BoundPriorityQueue[T, P]{
+		DoublePriorityQueue: *NewOrderedDoublePriorityQueue[T, P](),
+		capacity:            capacity,
+	}
+}
+
+// Capacity returns the BoundPriorityQueue's capacity.
+func (pq *BoundPriorityQueue[T, P]) Capacity() uint {
+	return pq.capacity
+}
+
+// Full checks if the BoundPriorityQueue is full.
+func (pq *BoundPriorityQueue[T, P]) Full() bool {
+	pq.RLock()
+	defer pq.RUnlock()
+
+	return uint(len(pq.items)) >= pq.capacity
+}
+
+// Push inserts the value in the BoundPriorityQueue with the provided
+// priority in at most *O(log n)* time complexity.
+//
+// If the BoundPriorityQueue is full, its lowest priority item is evicted in
+// favor of the pushed one. If the pushed item's priority does not exceed the
+// lowest one, the pushed item is rejected instead. Either way, Push returns
+// the evicted item, and whether an item was evicted.
+func (pq *BoundPriorityQueue[T, P]) Push(value T, priority P) (evictedValue T, evictedPriority P, evicted bool) {
+	pq.Lock()
+	defer pq.Unlock()
+
+	if uint(len(pq.items)) < pq.capacity {
+		pq.push(value, priority)
+		return evictedValue, evictedPriority, false
+	}
+
+	k, ok := pq.minIndex()
+	if !ok || !pq.less(pq.items[k].Priority, priority) {
+		return value, priority, true
+	}
+
+	entry := pq.removeAt(k)
+	pq.push(value, priority)
+
+	return entry.Value, entry.Priority, true
+}
+
+// ToSlice returns a copy of the BoundPriorityQueue's values and priorities,
+// from the highest to the lowest priority, in *O(n log n)* time complexity.
+func (pq *BoundPriorityQueue[T, P]) ToSlice() (values []T, priorities []P) {
+	pq.RLock()
+	entries := slices.Clone(pq.items)
+	pq.RUnlock()
+
+	// Sorting happens outside of the critical section.
+	slices.SortFunc(entries, func(lhs, rhs priorityQueueEntry[T, P]) int {
+		switch {
+		case pq.less(rhs.Priority, lhs.Priority):
+			return -1
+		case pq.less(lhs.Priority, rhs.Priority):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	values = make([]T, len(entries))
+	priorities = make([]P, len(entries))
+
+	for i, entry := range entries {
+		values[i] = entry.Value
+		priorities[i] = entry.Priority
+	}
+
+	return values, priorities
+}
@@ -0,0 +1,128 @@
+package lane
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundPriorityQueuePush(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc                string
+		pqueue              *BoundPriorityQueue[string, int]
+		priority            int
+		wantEvictedValue    string
+		wantEvictedPriority int
+		wantEvicted         bool
+		wantPriorities      []int
+	}{
+		{
+			desc:           "Push to BoundPriorityQueue with available space",
+			pqueue:         boundPriorityQueue(3, 40, 41),
+			priority:       42,
+			wantEvicted:    false,
+			wantPriorities: []int{42, 41, 40},
+		},
+		{
+			desc:                "Push to full BoundPriorityQueue evicts the lowest priority item",
+			pqueue:              boundPriorityQueue(2, 40, 41),
+			priority:            42,
+			wantEvictedValue:    "40",
+			wantEvictedPriority: 40,
+			wantEvicted:         true,
+			wantPriorities:      []int{42, 41},
+		},
+		{
+			desc:                "Push to full BoundPriorityQueue rejects lower priority items",
+			pqueue:              boundPriorityQueue(2, 40, 41),
+			priority:            39,
+			wantEvictedValue:    "39",
+			wantEvictedPriority: 39,
+			wantEvicted:         true,
+			wantPriorities:      []int{41, 40},
+		},
+		{
+			desc:                "Push to full BoundPriorityQueue rejects items of equal priority",
+			pqueue:              boundPriorityQueue(2, 40, 41),
+			priority:            40,
+			wantEvictedValue:    "40",
+			wantEvictedPriority: 40,
+			wantEvicted:         true,
+			wantPriorities:      []int{41, 40},
+		},
+		{
+			desc:                "Push to BoundPriorityQueue with null capacity",
+			pqueue:              boundPriorityQueue(0),
+			priority:            42,
+			wantEvictedValue:    "42",
+			wantEvictedPriority: 42,
+			wantEvicted:         true,
+			wantPriorities:      []int{},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			gotEvictedValue, gotEvictedPriority, gotEvicted := tC.pqueue.Push(strconv.Itoa(tC.priority), tC.priority)
+
+			assert.Equal(t, tC.wantEvictedValue, gotEvictedValue)
+			assert.Equal(t, tC.wantEvictedPriority, gotEvictedPriority)
+			assert.Equal(t, tC.wantEvicted, gotEvicted)
+
+			_, gotPriorities := tC.pqueue.ToSlice()
+			assert.Equal(t, tC.wantPriorities, gotPriorities)
+		})
+	}
+}
+
+func TestBoundPriorityQueueTopK(t *testing.T) {
+	t.Parallel()
+
+	pqueue := NewBoundPriorityQueue[string](3, func(lhs, rhs float64) bool {
+		return lhs < rhs
+	})
+
+	for i, score := range []float64{0.5, 0.9, 0.1, 0.7, 0.3, 0.8} {
+		pqueue.Push(strconv.Itoa(i), score)
+	}
+
+	gotValues, gotPriorities := pqueue.ToSlice()
+	assert.Equal(t, []string{"1", "5", "3"}, gotValues)
+	assert.Equal(t, []float64{0.9, 0.8, 0.7}, gotPriorities)
+
+	assert.Equal(t, uint(3), pqueue.Capacity())
+	assert.True(t, pqueue.Full())
+
+	gotValue, _, gotOk := pqueue.PopMax()
+	assert.Equal(t, "1", gotValue)
+	assert.True(t, gotOk)
+	assert.False(t, pqueue.Full())
+}
+
+func BenchmarkBoundPriorityQueuePush(b *testing.B) {
+	b.ReportAllocs()
+
+	pqueue := NewOrderedBoundPriorityQueue[int, int](1024)
+
+	for i := 0; i < b.N; i++ {
+		pqueue.Push(i, i)
+	}
+}
+
+// boundPriorityQueue returns a BoundPriorityQueue with the provided
+// capacity, holding items of the provided priorities.
+func boundPriorityQueue(capacity uint, priorities ...int) *BoundPriorityQueue[string, int] {
+	pqueue := NewOrderedBoundPriorityQueue[string, int](capacity)
+	for _, priority := range priorities {
+		pqueue.Push(strconv.Itoa(priority), priority)
+	}
+
+	return pqueue
+}
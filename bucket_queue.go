@@ -0,0 +1,168 @@
+package lane
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrPriorityOutOfRange is returned when pushing an item into a BucketQueue
+// with a priority exceeding its number of levels.
+var ErrPriorityOutOfRange = errors.New("lane: priority exceeds the BucketQueue's levels")
+
+// BucketQueue is a priority queue specialized for a small, bounded range of
+// integer priorities, such as a handful of job priority levels.
+//
+// It holds one FIFO bucket per priority level: items of equal priority are
+// served in the order they were pushed. Pushing an item happens in amortized
+// *O(1)* time complexity, and popping it in amortized *O(1)* too, levels
+// being scanned at most once between two pushes of a higher priority item.
+//
+// It can either be min (ascending) or max (descending) oriented/ordered,
+// and exposes the same method names as PriorityQueue, so that it can be
+// substituted for one.
+//
+// Every operation on a BucketQueue is goroutine-safe.
+type BucketQueue[T any] struct {
+	sync.RWMutex
+
+	// buckets holds the items of each priority level, ordered by rank: the
+	// first bucket holds the items served first.
+	buckets []ring[T]
+
+	// first is the rank of the first bucket which may hold items.
+	first uint
+
+	// max defines whether the highest priorities are served first.
+	max bool
+
+	size uint
+}
+
+// NewMaxBucketQueue instantiates a new maximum oriented BucketQueue,
+// accepting priorities from 0 to levels-1.
+func NewMaxBucketQueue[T any](levels uint) *BucketQueue[T] {
+	return &BucketQueue[T]{
+		buckets: make([]ring[T], levels),
+		first:   levels,
+		max:     true,
+	}
+}
+
+// NewMinBucketQueue instantiates a new minimum oriented BucketQueue,
+// accepting priorities from 0 to levels-1.
+func NewMinBucketQueue[T any](levels uint) *BucketQueue[T] {
+	return &BucketQueue[T]{
+		buckets: make([]ring[T], levels),
+		first:   levels,
+		max:     false,
+	}
+}
+
+// Push inserts the value in the BucketQueue with the provided priority
+// in amortized *O(1)* time complexity.
+//
+// If priority exceeds the BucketQueue's levels, Push returns
+// ErrPriorityOutOfRange, and leaves the BucketQueue untouched.
+func (q *BucketQueue[T]) Push(value T, priority uint) error {
+	q.Lock()
+	defer q.Unlock()
+
+	if priority >= q.levels() {
+		return ErrPriorityOutOfRange
+	}
+
+	rank := q.rank(priority)
+	q.buckets[rank].pushBack(value)
+	q.size++
+
+	if rank < q.first {
+		q.first = rank
+	}
+
+	return nil
+}
+
+// Pop removes and returns the highest or lowest priority item (depending on
+// the orientation of your BucketQueue) from the BucketQueue in amortized
+// *O(1)* time complexity.
+func (q *BucketQueue[T]) Pop() (value T, priority uint, ok bool) {
+	q.Lock()
+	defer q.Unlock()
+
+	rank, ok := q.head()
+	if !ok {
+		return value, priority, false
+	}
+
+	value, _ = q.buckets[rank].popFront()
+	q.size--
+
+	return value, q.rank(rank), true
+}
+
+// Head returns the highest or lowest priority item (depending on the
+// orientation of your BucketQueue) from the BucketQueue in amortized
+// *O(1)* time complexity.
+func (q *BucketQueue[T]) Head() (value T, priority uint, ok bool) {
+	// Locking for writing, as skipping empty buckets moves the first one.
+	q.Lock()
+	defer q.Unlock()
+
+	rank, ok := q.head()
+	if !ok {
+		return value, priority, false
+	}
+
+	value, _ = q.buckets[rank].front()
+
+	return value, q.rank(rank), true
+}
+
+// Size returns the number of elements present in the BucketQueue.
+func (q *BucketQueue[T]) Size() uint {
+	q.RLock()
+	defer q.RUnlock()
+
+	return q.size
+}
+
+// Empty returns whether the BucketQueue is empty.
+func (q *BucketQueue[T]) Empty() bool {
+	q.RLock()
+	defer q.RUnlock()
+
+	return q.size == 0
+}
+
+// Levels returns the number of priority levels of the BucketQueue.
+func (q *BucketQueue[T]) Levels() uint {
+	return q.levels()
+}
+
+// head returns the rank of the first non-empty bucket, and false if
+// the BucketQueue is empty. Empty buckets it skips are not scanned
+// again until a higher priority item is pushed.
+func (q *BucketQueue[T]) head() (uint, bool) {
+	if q.size == 0 {
+		return 0, false
+	}
+
+	for q.buckets[q.first].Len() == 0 {
+		q.first++
+	}
+
+	return q.first, true
+}
+
+// rank converts a priority into the rank of its bucket, and conversely.
+func (q *BucketQueue[T]) rank(priority uint) uint {
+	if q.max {
+		return q.levels() - 1 - priority
+	}
+
+	return priority
+}
+
+func (q *BucketQueue[T]) levels() uint {
+	return uint(len(q.buckets))
+}
@@ -0,0 +1,176 @@
+package lane
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketQueuePush(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc     string
+		queue    *BucketQueue[string]
+		priority uint
+		wantErr  error
+		wantSize uint
+	}{
+		{
+			desc:     "Push the lowest priority",
+			queue:    NewMaxBucketQueue[string](16),
+			priority: 0,
+			wantSize: 1,
+		},
+		{
+			desc:     "Push the highest priority",
+			queue:    NewMinBucketQueue[string](16),
+			priority: 15,
+			wantSize: 1,
+		},
+		{
+			desc:     "Push a priority exceeding the levels",
+			queue:    NewMaxBucketQueue[string](16),
+			priority: 16,
+			wantErr:  ErrPriorityOutOfRange,
+			wantSize: 0,
+		},
+		{
+			desc:     "Push to a BucketQueue without levels",
+			queue:    NewMinBucketQueue[string](0),
+			priority: 0,
+			wantErr:  ErrPriorityOutOfRange,
+			wantSize: 0,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			gotErr := tC.queue.Push("a", tC.priority)
+
+			assert.ErrorIs(t, gotErr, tC.wantErr)
+			assert.Equal(t, tC.wantSize, tC.queue.Size())
+		})
+	}
+}
+
+func TestBucketQueuePop(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc           string
+		queue          *BucketQueue[string]
+		wantValues     []string
+		wantPriorities []uint
+	}{
+		{
+			desc:           "Pop from a max BucketQueue",
+			queue:          NewMaxBucketQueue[string](4),
+			wantValues:     []string{"d1", "d2", "b1", "b2", "a1"},
+			wantPriorities: []uint{3, 3, 1, 1, 0},
+		},
+		{
+			desc:           "Pop from a min BucketQueue",
+			queue:          NewMinBucketQueue[string](4),
+			wantValues:     []string{"a1", "b1", "b2", "d1", "d2"},
+			wantPriorities: []uint{0, 1, 1, 3, 3},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.NoError(t, tC.queue.Push("b1", 1))
+			assert.NoError(t, tC.queue.Push("d1", 3))
+			assert.NoError(t, tC.queue.Push("a1", 0))
+			assert.NoError(t, tC.queue.Push("d2", 3))
+			assert.NoError(t, tC.queue.Push("b2", 1))
+
+			gotValues := []string{}
+			gotPriorities := []uint{}
+
+			for {
+				headValue, headPriority, headOk := tC.queue.Head()
+
+				value, priority, ok := tC.queue.Pop()
+				assert.Equal(t, headOk, ok)
+				if !ok {
+					break
+				}
+
+				assert.Equal(t, headValue, value)
+				assert.Equal(t, headPriority, priority)
+
+				gotValues = append(gotValues, value)
+				gotPriorities = append(gotPriorities, priority)
+			}
+
+			assert.Equal(t, tC.wantValues, gotValues)
+			assert.Equal(t, tC.wantPriorities, gotPriorities)
+			assert.True(t, tC.queue.Empty())
+		})
+	}
+}
+
+func TestBucketQueueInterleaved(t *testing.T) {
+	t.Parallel()
+
+	// Pushing higher priority items between pops must bring the first
+	// non-empty bucket back up.
+	random := rand.New(rand.NewPCG(1, 2))
+	queue := NewMaxBucketQueue[int](16)
+	reference := NewMaxPriorityQueue[int, uint](WithStableOrdering())
+
+	for i := 0; i < 10000; i++ {
+		if random.IntN(3) > 0 {
+			priority := random.UintN(16)
+
+			assert.NoError(t, queue.Push(i, priority))
+			reference.Push(i, priority)
+
+			continue
+		}
+
+		wantValue, wantPriority, wantOk := reference.Pop()
+		gotValue, gotPriority, gotOk := queue.Pop()
+
+		assert.Equal(t, wantValue, gotValue)
+		assert.Equal(t, wantPriority, gotPriority)
+		assert.Equal(t, wantOk, gotOk)
+	}
+
+	assert.Equal(t, reference.Size(), queue.Size())
+}
+
+func BenchmarkBucketQueuePush(b *testing.B) {
+	b.ReportAllocs()
+
+	queue := NewMaxBucketQueue[int](16)
+
+	for i := 0; i < b.N; i++ {
+		_ = queue.Push(i, uint(i)%16)
+	}
+}
+
+func BenchmarkBucketQueuePop(b *testing.B) {
+	b.ReportAllocs()
+
+	queue := NewMaxBucketQueue[int](16)
+
+	for i := 0; i < b.N; i++ {
+		_ = queue.Push(i, uint(i)%16)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		queue.Pop()
+	}
+}
@@ -0,0 +1,201 @@
+package lane
+
+import "context"
+
+// Channels exposes the Queue as a pair of channels: items sent on in are
+// enqueued, and items are dequeued as out is received from. The Queue acts
+// as an unbounded buffer in between.
+//
+// Once in is closed, out is closed as soon as the Queue has been drained.
+// If ctx is done before that, out is closed right away, and the items left
+// are kept in the Queue. Items sent on in after that are still enqueued, so
+// that senders never block: in must be closed for the underlying goroutine
+// to exit.
+//
+// The Queue's head is taken out of it while waiting to be received from
+// out, and put back when an item is sent on in, or when ctx is done. While
+// the channels are open, the Queue should not be dequeued from by other
+// means, as it would break the ordering of the items received on out.
+func (q *Queue[T]) Channels(ctx context.Context) (in chan<- T, out <-chan T) {
+	deque := q.container
+
+	var pending T
+
+	take := func() (value T, ok bool) {
+		deque.Lock()
+		defer deque.Unlock()
+
+		// The Queue's head is the back of its container.
+		back := deque.container.Back()
+		if back == nil {
+			return value, false
+		}
+
+		pending = deque.container.Remove(back)
+
+		return pending, true
+	}
+
+	restore := func() {
+		deque.Lock()
+		defer deque.Unlock()
+
+		deque.container.PushBack(pending)
+		pending = *new(T)
+	}
+
+	sent := func() {
+		deque.Lock()
+		defer deque.Unlock()
+
+		deque.producers.signal()
+		deque.observer.removed(1, deque.container.Len())
+		pending = *new(T)
+	}
+
+	return channels(ctx, q.Enqueue, take, restore, sent)
+}
+
+// Channels exposes the PriorityQueue as a pair of channels: items sent on in
+// are pushed with the priority computed by the priority function, and the
+// highest or lowest priority items (depending on the comparison heuristic of
+// your PriorityQueue) are popped as out is received from. The PriorityQueue
+// acts as an unbounded buffer in between.
+//
+// Once in is closed, out is closed as soon as the PriorityQueue has been
+// drained. If ctx is done before that, out is closed right away, and the items
+// left are kept in the PriorityQueue. Items sent on in after that are still
+// pushed, so that senders never block: in must be closed for the underlying
+// goroutine to exit.
+//
+// The PriorityQueue's head is taken out of it while waiting to be received
+// from out, and put back, with its original priority and insertion rank, when
+// an item is sent on in, or when ctx is done. Items pushed by other means in
+// the meantime are thus served after it. While the channels are open, the
+// PriorityQueue should not be popped from by other means, as it would break
+// the ordering of the items received on out.
+func (pq *PriorityQueue[T, P]) Channels(ctx context.Context, priority func(T) P) (in chan<- T, out <-chan T) {
+	push := func(value T) {
+		pq.Push(value, priority(value))
+	}
+
+	var pending *priorityQueueItem[T, P]
+
+	take := func() (value T, ok bool) {
+		pq.Lock()
+		defer pq.Unlock()
+
+		if pq.size() < 1 {
+			return value, false
+		}
+
+		pending = pq.removeAt(1)
+
+		return pending.value, true
+	}
+
+	restore := func() {
+		pq.Lock()
+		defer pq.Unlock()
+
+		pq.attach(pending)
+		pq.swim(pq.size())
+		pending = nil
+	}
+
+	sent := func() {
+		pq.Lock()
+		defer pq.Unlock()
+
+		pq.observer.removed(1, pq.size())
+		pending = nil
+	}
+
+	return channels(ctx, push, take, restore, sent)
+}
+
+// channels starts a goroutine moving the items received on the returned
+// in channel into a container, using push, and sending the container's
+// items on the returned out channel.
+//
+// The item to be sent next is taken out of the container using take before
+// being sent, so that items pushed concurrently cannot be mistaken for it.
+// It is put back into the container using restore whenever an item is
+// received, so that the container orders them, or when ctx is done. Once
+// it has been sent, sent is called.
+//
+// Once ctx is done, out is closed, and the items received on in are pushed
+// into the container until it is closed.
+func channels[T any](
+	ctx context.Context,
+	push func(T),
+	take func() (T, bool),
+	restore func(),
+	sent func(),
+) (chan<- T, <-chan T) {
+	in := make(chan T)
+	out := make(chan T)
+
+	go func() {
+		// Once closed, the input channel is set to nil,
+		// so that it is not selected anymore.
+		recv := (<-chan T)(in)
+
+		var next T
+		var pending bool
+
+		for {
+			if !pending {
+				next, pending = take()
+			}
+
+			if !pending && recv == nil {
+				close(out)
+				return
+			}
+
+			// As long as there is no item to send, the
+			// output channel is not selected.
+			var send chan<- T
+			if pending {
+				send = out
+			}
+
+			select {
+			case send <- next:
+				sent()
+				pending = false
+			case item, open := <-recv:
+				if !open {
+					recv = nil
+					continue
+				}
+
+				if pending {
+					restore()
+					pending = false
+				}
+
+				push(item)
+			case <-ctx.Done():
+				if pending {
+					restore()
+				}
+
+				close(out)
+
+				// Keep receiving, so that senders do not block
+				// forever on an input channel nobody reads.
+				if recv != nil {
+					for item := range recv {
+						push(item)
+					}
+				}
+
+				return
+			}
+		}
+	}()
+
+	return in, out
+}
@@ -0,0 +1,202 @@
+package lane
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueueChannels(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		queue     *Queue[int]
+		send      []int
+		wantItems []int
+	}{
+		{
+			desc:  "closing the input of an empty Queue closes the output",
+			queue: NewQueue[int](),
+		},
+		{
+			desc:      "items sent are received in FIFO order",
+			queue:     NewQueue[int](),
+			send:      []int{1, 2, 3},
+			wantItems: []int{1, 2, 3},
+		},
+		{
+			desc:      "items already in the Queue are received first",
+			queue:     NewQueue([]int{1, 2}...),
+			send:      []int{3},
+			wantItems: []int{1, 2, 3},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			in, out := tC.queue.Channels(context.Background())
+
+			// Sending everything before receiving anything
+			// requires the buffering to be unbounded.
+			for _, item := range tC.send {
+				in <- item
+			}
+			close(in)
+
+			assert.Equal(t, tC.wantItems, receiveAll(out))
+			assert.Equal(t, uint(0), tC.queue.Size())
+		})
+	}
+}
+
+func TestQueueChannelsContextCancellation(t *testing.T) {
+	t.Parallel()
+
+	queue := NewQueue[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in, out := queue.Channels(ctx)
+	in <- 1
+	in <- 2
+
+	assert.Equal(t, 1, <-out)
+
+	cancel()
+
+	select {
+	case _, ok := <-out:
+		// A pending send might win the race against
+		// cancellation, but the channel gets closed next.
+		if ok {
+			_, ok = <-out
+		}
+
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("output channel was not closed once the context was done")
+	}
+}
+
+func TestQueueChannelsSendAfterContextCancellation(t *testing.T) {
+	t.Parallel()
+
+	queue := NewQueue[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in, out := queue.Channels(ctx)
+	cancel()
+
+	_, ok := <-out
+	assert.False(t, ok)
+
+	for _, item := range []int{1, 2, 3} {
+		select {
+		case in <- item:
+		case <-time.After(time.Second):
+			t.Fatal("sending on the input channel blocked once the context was done")
+		}
+	}
+
+	close(in)
+
+	assert.Eventually(t, func() bool {
+		return queue.Size() == 3
+	}, time.Second, time.Millisecond)
+
+	for _, want := range []int{1, 2, 3} {
+		got, ok := queue.Dequeue()
+		assert.True(t, ok)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestPriorityQueueChannels(t *testing.T) {
+	t.Parallel()
+
+	pqueue := NewMaxPriorityQueue[int, int]()
+	in, out := pqueue.Channels(context.Background(), func(value int) int { return value })
+
+	for _, item := range []int{2, 5, 1, 4, 3} {
+		in <- item
+	}
+	close(in)
+
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, receiveAll(out))
+	assert.True(t, pqueue.Empty())
+}
+
+func TestPriorityQueueChannelsConcurrentPush(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc   string
+		pushed int
+	}{
+		{
+			desc:   "a single item pushed by other means is neither lost nor duplicated",
+			pushed: 1,
+		},
+		{
+			desc:   "items pushed concurrently by other means are neither lost nor duplicated",
+			pushed: 1000,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			pqueue := NewMaxPriorityQueue[int, int]()
+			pqueue.Push(-1, -1)
+
+			in, out := pqueue.Channels(context.Background(), func(value int) int { return value })
+
+			want := []int{-1}
+			pushed := make(chan struct{})
+
+			go func() {
+				defer close(pushed)
+
+				// Higher priority items are pushed while the adapter
+				// is about to send the current head.
+				for i := 0; i < tC.pushed; i++ {
+					pqueue.Push(i, i)
+				}
+			}()
+
+			for i := 0; i < tC.pushed; i++ {
+				want = append(want, i)
+			}
+
+			got := []int{<-out}
+
+			<-pushed
+			close(in)
+
+			got = append(got, receiveAll(out)...)
+
+			assert.ElementsMatch(t, want, got)
+			assert.True(t, pqueue.Empty())
+		})
+	}
+}
+
+// receiveAll receives from out until it is closed, and returns the received items.
+func receiveAll[T any](out <-chan T) []T {
+	var items []T
+
+	for item := range out {
+		items = append(items, item)
+	}
+
+	return items
+}
@@ -0,0 +1,162 @@
+package lane
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time, and timers, to a DelayQueue.
+//
+// It allows substituting the system's clock, for instance in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the
+	// current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock relying on the system's time.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// DelayQueue is a queue whose items can only be taken out once their
+// deadline has passed.
+//
+// Built upon a min oriented PriorityQueue keyed on deadlines, it releases
+// its items by ascending deadline, and those sharing the same deadline in
+// the order they were pushed. Push and Take operations have a time complexity
+// of *O(log n)*.
+//
+// Every operation on a DelayQueue is goroutine-safe.
+type DelayQueue[T any] struct {
+	sync.Mutex
+
+	// The underlying storage container.
+	container *PriorityQueue[T, time.Time]
+
+	clock Clock
+
+	// consumers holds the goroutines blocked in Take, waiting for an
+	// item to be pushed, or for the earliest deadline to pass.
+	consumers waitList
+}
+
+// DelayQueueOption configures a DelayQueue at construction.
+type DelayQueueOption func(*delayQueueOptions)
+
+// delayQueueOptions holds the configuration of a DelayQueue.
+type delayQueueOptions struct {
+	clock Clock
+}
+
+// WithClock makes a DelayQueue rely on the provided clock, rather than
+// on the system's one.
+func WithClock(clock Clock) DelayQueueOption {
+	return func(options *delayQueueOptions) {
+		options.clock = clock
+	}
+}
+
+// NewDelayQueue produces a new DelayQueue instance.
+func NewDelayQueue[T any](options ...DelayQueueOption) *DelayQueue[T] {
+	opts := delayQueueOptions{clock: systemClock{}}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	return &DelayQueue[T]{
+		container: NewPriorityQueue[T](func(lhs, rhs time.Time) bool {
+			return rhs.Before(lhs)
+		}, WithStableOrdering()),
+		clock: opts.clock,
+	}
+}
+
+// Push inserts value in the DelayQueue, to be released once the
+// deadline has passed, in at most *O(log n)* time complexity.
+func (dq *DelayQueue[T]) Push(value T, deadline time.Time) {
+	dq.Lock()
+	defer dq.Unlock()
+
+	dq.container.Push(value, deadline)
+	dq.consumers.signal()
+}
+
+// PushAfter inserts value in the DelayQueue, to be released once
+// the delay has elapsed, in at most *O(log n)* time complexity.
+func (dq *DelayQueue[T]) PushAfter(value T, delay time.Duration) {
+	dq.Push(value, dq.clock.Now().Add(delay))
+}
+
+// Poll removes and returns the DelayQueue's item with the earliest deadline,
+// if that deadline has passed. Otherwise, ok is false.
+func (dq *DelayQueue[T]) Poll() (value T, deadline time.Time, ok bool) {
+	dq.Lock()
+	defer dq.Unlock()
+
+	value, deadline, ok = dq.container.Head()
+	if !ok || deadline.After(dq.clock.Now()) {
+		var zero T
+		return zero, time.Time{}, false
+	}
+
+	dq.container.Pop()
+
+	return value, deadline, true
+}
+
+// Take removes and returns the DelayQueue's item with the earliest deadline,
+// once that deadline has passed. It blocks until then, or until ctx is done,
+// in which case it returns the context's error.
+//
+// While blocked, Take sleeps until the earliest deadline, and wakes up
+// earlier if an item with an earlier deadline is pushed in the meantime.
+func (dq *DelayQueue[T]) Take(ctx context.Context) (value T, err error) {
+	dq.Lock()
+	defer dq.Unlock()
+
+	for {
+		var timeout <-chan time.Time
+
+		if head, deadline, ok := dq.container.Head(); ok {
+			delay := deadline.Sub(dq.clock.Now())
+			if delay <= 0 {
+				dq.container.Pop()
+				return head, nil
+			}
+
+			timeout = dq.clock.After(delay)
+		}
+
+		if err = dq.consumers.waitTimeout(ctx, &dq.Mutex, timeout); err != nil {
+			return value, err
+		}
+	}
+}
+
+// Head returns the DelayQueue's item with the earliest deadline, whether
+// it has passed or not, in *O(1)* time complexity.
+func (dq *DelayQueue[T]) Head() (value T, deadline time.Time, ok bool) {
+	return dq.container.Head()
+}
+
+// Size returns the number of items in the DelayQueue, whether their
+// deadline has passed or not.
+func (dq *DelayQueue[T]) Size() uint {
+	return dq.container.Size()
+}
+
+// Empty returns whether the DelayQueue is empty.
+func (dq *DelayQueue[T]) Empty() bool {
+	return dq.container.Empty()
+}
@@ -0,0 +1,241 @@
+package lane
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelayQueuePoll(t *testing.T) {
+	t.Parallel()
+
+	epoch := time.Unix(0, 0)
+
+	testCases := []struct {
+		desc         string
+		push         map[string]time.Duration
+		wantValue    string
+		wantDeadline time.Time
+		wantOk       bool
+		wantSize     uint
+	}{
+		{
+			desc: "Poll on an empty DelayQueue",
+		},
+		{
+			desc:     "Poll before the earliest deadline",
+			push:     map[string]time.Duration{"a": time.Second, "b": time.Minute},
+			wantSize: 2,
+		},
+		{
+			desc:         "Poll after the earliest deadline",
+			push:         map[string]time.Duration{"a": -time.Second, "b": time.Minute},
+			wantValue:    "a",
+			wantDeadline: epoch.Add(-time.Second),
+			wantOk:       true,
+			wantSize:     1,
+		},
+		{
+			desc:         "Poll exactly at the earliest deadline",
+			push:         map[string]time.Duration{"a": 0, "b": time.Minute},
+			wantValue:    "a",
+			wantDeadline: epoch,
+			wantOk:       true,
+			wantSize:     1,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			queue := NewDelayQueue[string](WithClock(newFakeClock(epoch)))
+			for value, delay := range tC.push {
+				queue.PushAfter(value, delay)
+			}
+
+			gotValue, gotDeadline, gotOk := queue.Poll()
+
+			assert.Equal(t, tC.wantValue, gotValue)
+			assert.Equal(t, tC.wantDeadline, gotDeadline)
+			assert.Equal(t, tC.wantOk, gotOk)
+			assert.Equal(t, tC.wantSize, queue.Size())
+		})
+	}
+}
+
+func TestDelayQueueReleasesItemsByDeadline(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock(time.Unix(0, 0))
+	queue := NewDelayQueue[string](WithClock(clock))
+
+	queue.PushAfter("c", 3*time.Second)
+	queue.PushAfter("a", time.Second)
+	queue.PushAfter("b1", 2*time.Second)
+	queue.PushAfter("b2", 2*time.Second)
+
+	clock.Advance(5 * time.Second)
+
+	var got []string
+	for {
+		value, _, ok := queue.Poll()
+		if !ok {
+			break
+		}
+
+		got = append(got, value)
+	}
+
+	assert.Equal(t, []string{"a", "b1", "b2", "c"}, got)
+	assert.True(t, queue.Empty())
+}
+
+func TestDelayQueueTake(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock(time.Unix(0, 0))
+	queue := NewDelayQueue[string](WithClock(clock))
+
+	result := make(chan string)
+	go func() {
+		value, err := queue.Take(context.Background())
+		assert.NoError(t, err)
+
+		result <- value
+	}()
+
+	// Take waits for an item to be pushed.
+	assert.Eventually(t, func() bool { return delayQueueWaiting(queue) == 1 }, time.Second, time.Millisecond)
+	queue.PushAfter("later", time.Minute)
+
+	// Then sleeps until its deadline, but wakes up when
+	// an item with an earlier deadline is pushed.
+	assert.Eventually(t, func() bool { return clock.Timers() == 1 }, time.Second, time.Millisecond)
+	queue.PushAfter("sooner", time.Second)
+	assert.Eventually(t, func() bool { return clock.Timers() == 2 }, time.Second, time.Millisecond)
+
+	clock.Advance(time.Second)
+
+	assert.Equal(t, "sooner", <-result)
+	assert.Equal(t, uint(1), queue.Size())
+}
+
+func TestDelayQueueTakeContextCancellation(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock(time.Unix(0, 0))
+	queue := NewDelayQueue[string](WithClock(clock))
+	queue.PushAfter("later", time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	gotValue, gotErr := queue.Take(ctx)
+
+	assert.ErrorIs(t, gotErr, context.DeadlineExceeded)
+	assert.Equal(t, "", gotValue)
+	assert.Equal(t, uint(1), queue.Size())
+}
+
+func TestDelayQueueTakeWithSystemClock(t *testing.T) {
+	t.Parallel()
+
+	queue := NewDelayQueue[int]()
+	start := time.Now()
+	queue.PushAfter(42, 20*time.Millisecond)
+
+	gotValue, gotErr := queue.Take(context.Background())
+
+	assert.NoError(t, gotErr)
+	assert.Equal(t, 42, gotValue)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+func BenchmarkDelayQueuePush(b *testing.B) {
+	b.ReportAllocs()
+
+	queue := NewDelayQueue[int]()
+	now := time.Now()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		queue.Push(i, now.Add(time.Duration(i)))
+	}
+}
+
+// delayQueueWaiting returns the number of consumers blocked on queue.
+func delayQueueWaiting[T any](queue *DelayQueue[T]) uint {
+	queue.Lock()
+	defer queue.Unlock()
+
+	return queue.consumers.waiters.Len()
+}
+
+// fakeClock is a Clock whose time only moves forward when advanced.
+type fakeClock struct {
+	sync.Mutex
+
+	now    time.Time
+	timers []fakeTimer
+}
+
+// fakeTimer is a timer created by fakeClock.After.
+type fakeTimer struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	ch := make(chan time.Time, 1)
+	c.timers = append(c.timers, fakeTimer{deadline: c.now.Add(d), ch: ch})
+
+	return ch
+}
+
+// Advance moves the clock forward by d, firing the timers which expire.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.now = c.now.Add(d)
+
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.deadline.After(c.now) {
+			pending = append(pending, timer)
+			continue
+		}
+
+		timer.ch <- c.now
+	}
+
+	c.timers = pending
+}
+
+// Timers returns the number of timers created, and not fired yet.
+func (c *fakeClock) Timers() int {
+	c.Lock()
+	defer c.Unlock()
+
+	return len(c.timers)
+}
@@ -1,6 +1,10 @@
 package lane
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
 	"sync"
 )
 
@@ -22,6 +26,17 @@ type Deque[T any] struct {
 
 	// The underlying storage container.
 	container *List[T]
+
+	// consumers holds the goroutines blocked in PopWait or ShiftWait,
+	// waiting for an item to be inserted.
+	consumers waitList
+
+	// producers holds the goroutines blocked in a BoundDeque's AppendWait
+	// or PrependWait, waiting for an item to be removed.
+	producers waitList
+
+	// observer is notified of the operations performed on the Deque.
+	observer observation
 }
 
 // NewDeque produces a new Deque instance.
@@ -43,6 +58,8 @@ func (d *Deque[T]) Append(item T) {
 	defer d.Unlock()
 
 	d.container.PushBack(item)
+	d.consumers.signal()
+	d.observer.inserted(1, d.container.Len())
 }
 
 // Prepend inserts item at the Deque's front in an *O(1)* time complexity.
@@ -51,6 +68,43 @@ func (d *Deque[T]) Prepend(item T) {
 	defer d.Unlock()
 
 	d.container.PushFront(item)
+	d.consumers.signal()
+	d.observer.inserted(1, d.container.Len())
+}
+
+// AppendAll inserts items at the back of the Deque, in order, in an *O(k)*
+// time complexity, k being the number of items.
+//
+// It is equivalent to calling Append for each item, but only acquires
+// the Deque's lock once.
+func (d *Deque[T]) AppendAll(items ...T) {
+	d.Lock()
+	defer d.Unlock()
+
+	for _, item := range items {
+		d.container.PushBack(item)
+	}
+
+	d.consumers.signalN(len(items))
+	d.observer.inserted(uint(len(items)), d.container.Len())
+}
+
+// PrependAll inserts items at the Deque's front, in order, in an *O(k)*
+// time complexity, k being the number of items. The last item thus ends up
+// at the front of the Deque.
+//
+// It is equivalent to calling Prepend for each item, but only acquires
+// the Deque's lock once.
+func (d *Deque[T]) PrependAll(items ...T) {
+	d.Lock()
+	defer d.Unlock()
+
+	for _, item := range items {
+		d.container.PushFront(item)
+	}
+
+	d.consumers.signalN(len(items))
+	d.observer.inserted(uint(len(items)), d.container.Len())
 }
 
 // Pop removes and returns the back element of the Deque in an *O(1)* time complexity.
@@ -62,6 +116,8 @@ func (d *Deque[T]) Pop() (item T, ok bool) {
 	if lastElement != nil {
 		item = d.container.Remove(lastElement)
 		ok = true
+		d.producers.signal()
+		d.observer.removed(1, d.container.Len())
 	}
 
 	return
@@ -76,11 +132,51 @@ func (d *Deque[T]) Shift() (item T, ok bool) {
 	if firstElement != nil {
 		item = d.container.Remove(firstElement)
 		ok = true
+		d.producers.signal()
+		d.observer.removed(1, d.container.Len())
 	}
 
 	return
 }
 
+// PopN removes and returns up to n elements from the back of the Deque, in
+// an *O(n)* time complexity. Items are returned in the order successive calls
+// to Pop would have returned them.
+//
+// It only acquires the Deque's lock once.
+func (d *Deque[T]) PopN(n uint) []T {
+	return d.removeN(n, d.container.Back)
+}
+
+// ShiftN removes and returns up to n elements from the front of the Deque, in
+// an *O(n)* time complexity. Items are returned in the order successive calls
+// to Shift would have returned them.
+//
+// It only acquires the Deque's lock once.
+func (d *Deque[T]) ShiftN(n uint) []T {
+	return d.removeN(n, d.container.Front)
+}
+
+// PopWait removes and returns the back element of the Deque. If the Deque
+// is empty, it blocks until an item is inserted, or until ctx is done, in which
+// case it returns the context's error.
+//
+// Each insertion wakes a single blocked goroutine, in the order they
+// started waiting.
+func (d *Deque[T]) PopWait(ctx context.Context) (item T, err error) {
+	return d.removeWait(ctx, d.container.Back)
+}
+
+// ShiftWait removes and returns the front element of the Deque. If the Deque
+// is empty, it blocks until an item is inserted, or until ctx is done, in which
+// case it returns the context's error.
+//
+// Each insertion wakes a single blocked goroutine, in the order they
+// started waiting.
+func (d *Deque[T]) ShiftWait(ctx context.Context) (item T, err error) {
+	return d.removeWait(ctx, d.container.Front)
+}
+
 // First returns the first value stored in the Deque in *O(1)* time complexity.
 func (d *Deque[T]) First() (item T, ok bool) {
 	d.RLock()
@@ -124,18 +220,230 @@ func (d *Deque[T]) Empty() bool {
 	return d.container.Len() == 0
 }
 
+// SetObserver registers observer to be notified of the operations performed
+// on the Deque, replacing any previously registered one. A nil observer
+// unregisters it.
+func (d *Deque[T]) SetObserver(observer Observer) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.observer.observer = observer
+}
+
+// removeN removes and returns up to n elements, designated one
+// after the other by the elem function.
+func (d *Deque[T]) removeN(n uint, elem func() *Element[T]) []T {
+	d.Lock()
+	defer d.Unlock()
+
+	if n > d.container.Len() {
+		n = d.container.Len()
+	}
+
+	items := make([]T, 0, n)
+	for uint(len(items)) < n {
+		items = append(items, d.container.Remove(elem()))
+	}
+
+	d.producers.signalN(len(items))
+	d.observer.removed(uint(len(items)), d.container.Len())
+
+	return items
+}
+
+// removeWait removes and returns the element designated by the elem function,
+// waiting for the Deque to hold one if necessary.
+func (d *Deque[T]) removeWait(ctx context.Context, elem func() *Element[T]) (item T, err error) {
+	d.Lock()
+	defer d.Unlock()
+
+	for {
+		if e := elem(); e != nil {
+			item = d.container.Remove(e)
+			d.producers.signal()
+			d.observer.removed(1, d.container.Len())
+
+			return item, nil
+		}
+
+		if err = d.observer.wait(ctx, &d.consumers, &d.RWMutex); err != nil {
+			return item, err
+		}
+	}
+}
+
+// Clear removes all the elements from the Deque in *O(1)* time complexity.
+func (d *Deque[T]) Clear() {
+	d.Lock()
+	defer d.Unlock()
+
+	removed := d.container.Len()
+	d.container.Init()
+
+	d.producers.signalN(int(removed))
+	d.observer.removed(removed, 0)
+}
+
+// Clone returns a copy of the Deque, in *O(n)* time complexity.
+func (d *Deque[T]) Clone() *Deque[T] {
+	return NewDeque(d.snapshot()...)
+}
+
+// ToSlice returns a copy of the Deque's items, from front to back,
+// in *O(n)* time complexity.
+func (d *Deque[T]) ToSlice() []T {
+	return d.snapshot()
+}
+
+// All returns an iterator over the Deque's items, from front to back.
+//
+// The iterator walks over a snapshot of the Deque, taken when All is called:
+// the Deque can safely be modified while iterating.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return forward(d.snapshot())
+}
+
+// Backward returns an iterator over the Deque's items, from back to front.
+//
+// The iterator walks over a snapshot of the Deque, taken when Backward is called:
+// the Deque can safely be modified while iterating.
+func (d *Deque[T]) Backward() iter.Seq[T] {
+	return backward(d.snapshot())
+}
+
+// MarshalJSON encodes the Deque's items as a JSON array, from front to back.
+func (d *Deque[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.snapshot())
+}
+
+// UnmarshalJSON replaces the Deque's items with the ones of the
+// provided JSON array, from front to back.
+func (d *Deque[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	d.replace(items)
+
+	return nil
+}
+
+// MarshalBinary encodes the Deque's items, from front to back, using gob.
+func (d *Deque[T]) MarshalBinary() ([]byte, error) {
+	return marshalGob(d.snapshot())
+}
+
+// UnmarshalBinary replaces the Deque's items with the ones encoded
+// by MarshalBinary.
+func (d *Deque[T]) UnmarshalBinary(data []byte) error {
+	items, err := unmarshalGob[[]T](data)
+	if err != nil {
+		return err
+	}
+
+	d.replace(items)
+
+	return nil
+}
+
+// replace replaces the Deque's items with the provided ones, from front to back.
+func (d *Deque[T]) replace(items []T) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.reset(items)
+}
+
+// reset replaces the Deque's items with the provided ones, from front to back.
+//
+// The caller must hold the Deque's lock.
+func (d *Deque[T]) reset(items []T) {
+	// The container is reset in place, rather than reallocated, as
+	// blocked producers hold on to its methods while waiting.
+	if d.container == nil {
+		d.container = New[T]()
+	}
+
+	previous := d.container.Len()
+	d.observer.removed(previous, 0)
+
+	d.container.Init()
+	for _, item := range items {
+		d.container.PushBack(item)
+	}
+
+	d.consumers.signalN(len(items))
+	d.observer.inserted(uint(len(items)), d.container.Len())
+
+	// Holding fewer items than before frees slots for the
+	// producers blocked on a full BoundDeque.
+	if size := d.container.Len(); previous > size {
+		free := min(previous-size, d.producers.waiters.Len())
+		d.producers.signalN(int(free))
+	}
+}
+
+// snapshot returns a copy of the Deque's items, from front to back.
+func (d *Deque[T]) snapshot() []T {
+	d.RLock()
+	defer d.RUnlock()
+
+	return d.copyItems()
+}
+
+// copyItems returns a copy of the Deque's items, from front to back.
+//
+// The caller must hold the Deque's lock.
+func (d *Deque[T]) copyItems() []T {
+	items := make([]T, 0, d.container.Len())
+	for e := d.container.Front(); e != nil; e = e.Next() {
+		items = append(items, e.Value)
+	}
+
+	return items
+}
+
 // Capacitor defines operations related to capacity management.
 type Capacitor interface {
 	// Capacity returns the current capacity of the underlying type implementation.
-	Capacity() int
+	Capacity() uint
 
-	// IsFull returns whether the implementing type instance is full.
-	IsFull() bool
+	// Full returns whether the implementing type instance is full.
+	Full() bool
 }
 
+// BoundDeque implements Capacitor.
+var _ Capacitor = (*BoundDeque[int])(nil)
+
+// OverflowPolicy defines how a BoundDeque behaves when an item is
+// inserted while it is full.
+type OverflowPolicy int
+
+const (
+	// OverflowReject rejects the inserted item, and leaves
+	// the BoundDeque untouched. It is the default policy.
+	OverflowReject OverflowPolicy = iota
+
+	// OverflowDropOldest evicts the oldest item of the BoundDeque, at
+	// the opposite end from the insertion, to make room for the inserted
+	// one: the item at the front when appending, and the item at the back
+	// when prepending.
+	OverflowDropOldest
+
+	// OverflowDropNewest discards the inserted item, and reports it
+	// as evicted.
+	OverflowDropNewest
+
+	// OverflowBlock blocks the insertion until an item is removed
+	// from the BoundDeque.
+	OverflowBlock
+)
+
 // BoundDeque implements a head-tail linked list data structure
 // with a user-defined capacity. Once full, `Append` and `Prepend`
-// operations on a BoundedDeque fail.
+// operations on a BoundedDeque apply its OverflowPolicy, and fail
+// by default.
 //
 // Under the hood, BoundDeque's implementation relies upon a doubly linked list
 // container. Thus, every operation on a BoundedDeque has a time complexity of *O(1)*.
@@ -148,53 +456,371 @@ type BoundDeque[T any] struct {
 
 	// capacity defines an upper bound limit for the BoundDeque's size.
 	capacity uint
+
+	// policy defines how insertions behave once the BoundDeque is full.
+	policy OverflowPolicy
 }
 
 // NewBoundDeque produces a new BoundDeque instance with the provided
-// capacity.
+// capacity, rejecting insertions once full.
 func NewBoundDeque[T any](capacity uint, values ...T) *BoundDeque[T] {
+	return NewBoundDequeWithPolicy(capacity, OverflowReject, values...)
+}
+
+// NewBoundDequeWithPolicy produces a new BoundDeque instance with the
+// provided capacity, applying the provided overflow policy to insertions
+// once full.
+func NewBoundDequeWithPolicy[T any](capacity uint, policy OverflowPolicy, values ...T) *BoundDeque[T] {
 	return &BoundDeque[T]{
 		Deque:    *NewDeque(values...),
 		capacity: capacity,
+		policy:   policy,
 	}
 }
 
 // Capacity returns BoundDeque's capacity.
 func (d *BoundDeque[T]) Capacity() uint {
+	d.RLock()
+	defer d.RUnlock()
+
 	return d.capacity
 }
 
+// Policy returns the BoundDeque's overflow policy.
+func (d *BoundDeque[T]) Policy() OverflowPolicy {
+	return d.policy
+}
+
+// ShrinkPolicy defines how a BoundDeque's capacity is reduced below
+// its current size.
+type ShrinkPolicy int
+
+const (
+	// ShrinkReject rejects the capacity change, and leaves
+	// the BoundDeque untouched.
+	ShrinkReject ShrinkPolicy = iota
+
+	// ShrinkTruncateFront removes the items exceeding the new
+	// capacity from the BoundDeque's front.
+	ShrinkTruncateFront
+
+	// ShrinkTruncateBack removes the items exceeding the new
+	// capacity from the BoundDeque's back.
+	ShrinkTruncateBack
+)
+
+// SetCapacity changes the BoundDeque's capacity in an *O(k)* time
+// complexity, k being the number of removed items.
+//
+// If the new capacity is below the BoundDeque's size, the provided shrink
+// policy applies. SetCapacity returns the items it removed, from front to back,
+// and whether the capacity was changed. Growing the capacity wakes as many
+// blocked producers as there are free slots.
+func (d *BoundDeque[T]) SetCapacity(capacity uint, policy ShrinkPolicy) ([]T, bool) {
+	d.Lock()
+	defer d.Unlock()
+
+	var removed []T
+
+	if size := d.container.Len(); size > capacity {
+		excess := size - capacity
+		var elem func() *Element[T]
+
+		switch policy {
+		case ShrinkTruncateFront:
+			elem = d.container.Front
+		case ShrinkTruncateBack:
+			elem = d.container.Back
+		default:
+			return nil, false
+		}
+
+		removed = make([]T, excess)
+		for i := uint(0); i < excess; i++ {
+			item := d.container.Remove(elem())
+
+			// Items are returned from front to back, whichever
+			// end of the BoundDeque they are removed from.
+			if policy == ShrinkTruncateFront {
+				removed[i] = item
+			} else {
+				removed[excess-1-i] = item
+			}
+		}
+	}
+
+	d.capacity = capacity
+	d.observer.dropped(uint(len(removed)), d.container.Len())
+
+	if size := d.container.Len(); capacity > size {
+		// Bounding the free slots by the number of waiting producers
+		// ensures converting them to an int cannot overflow.
+		free := min(capacity-size, d.producers.waiters.Len())
+		d.producers.signalN(int(free))
+	}
+
+	return removed, true
+}
+
+// Clone returns a copy of the BoundDeque, with the same capacity
+// and overflow policy, in *O(n)* time complexity.
+func (d *BoundDeque[T]) Clone() *BoundDeque[T] {
+	d.RLock()
+	defer d.RUnlock()
+
+	return NewBoundDequeWithPolicy(d.capacity, d.policy, d.copyItems()...)
+}
+
 // Full checks if the BoundDeque is full.
 func (d *BoundDeque[T]) Full() bool {
+	d.RLock()
+	defer d.RUnlock()
+
+	return d.full()
+}
+
+// full checks if the BoundDeque is full.
+//
+// The caller must hold the BoundDeque's lock.
+func (d *BoundDeque[T]) full() bool {
 	return d.container.Len() >= d.capacity
 }
 
 // Append inserts an item at the back of the BoundDeque in an *O(1)* time complexity.
-// If BoundDeque's capacity disallows the insertion, Append returns false.
+// If the BoundDeque is full, its overflow policy applies, and Append returns
+// whether the item was inserted.
 func (d *BoundDeque[T]) Append(item T) bool {
+	_, _, inserted := d.AppendEvict(item)
+	return inserted
+}
+
+// Prepend inserts item at the BoundDeque's front in an *O(1)* time complexity.
+// If the BoundDeque is full, its overflow policy applies, and Prepend returns
+// whether the item was inserted.
+func (d *BoundDeque[T]) Prepend(item T) bool {
+	_, _, inserted := d.PrependEvict(item)
+	return inserted
+}
+
+// AppendEvict inserts an item at the back of the BoundDeque in an *O(1)* time
+// complexity. If the BoundDeque is full, its overflow policy applies, and
+// AppendEvict returns the item it evicted, if any, alongside whether an item
+// was evicted, and whether item was inserted.
+//
+// Under the OverflowDropNewest policy, the evicted item is item itself.
+func (d *BoundDeque[T]) AppendEvict(item T) (evicted T, dropped, inserted bool) {
 	d.Lock()
 	defer d.Unlock()
 
-	if d.Full() {
-		return false
+	evicted, dropped, inserted = d.insert(item, d.container.PushBack, d.container.Front)
+	if inserted {
+		d.consumers.signal()
 	}
 
-	d.container.PushBack(item)
+	return evicted, dropped, inserted
+}
+
+// PrependEvict inserts item at the BoundDeque's front in an *O(1)* time
+// complexity. If the BoundDeque is full, its overflow policy applies, and
+// PrependEvict returns the item it evicted, if any, alongside whether an item
+// was evicted, and whether item was inserted.
+//
+// Under the OverflowDropNewest policy, the evicted item is item itself.
+func (d *BoundDeque[T]) PrependEvict(item T) (evicted T, dropped, inserted bool) {
+	d.Lock()
+	defer d.Unlock()
+
+	evicted, dropped, inserted = d.insert(item, d.container.PushFront, d.container.Back)
+	if inserted {
+		d.consumers.signal()
+	}
 
-	return true
+	return evicted, dropped, inserted
 }
 
-// Prepend inserts item at the BoundDeque's front in an *O(1)* time complexity.
-// If BoundDeque's capacity disallows the insertion, Prepend returns false.
-func (d *BoundDeque[T]) Prepend(item T) bool {
+// insert inserts item using the push function, applying the BoundDeque's
+// overflow policy if it is full. The oldest function returns the element
+// OverflowDropOldest evicts.
+//
+// The caller must hold the BoundDeque's lock, and is responsible for
+// signaling consumers.
+func (d *BoundDeque[T]) insert(
+	item T,
+	push func(T) *Element[T],
+	oldest func() *Element[T],
+) (evicted T, dropped, inserted bool) {
+	if !d.full() {
+		push(item)
+		d.observer.inserted(1, d.container.Len())
+
+		return evicted, false, true
+	}
+
+	switch d.policy {
+	case OverflowDropOldest:
+		element := oldest()
+		if element == nil {
+			// A zero capacity BoundDeque has nothing to evict
+			// in favor of item, which is dropped instead.
+			d.observer.dropped(1, 0)
+
+			return item, true, false
+		}
+
+		evicted = d.container.Remove(element)
+		d.observer.dropped(1, d.container.Len())
+
+		push(item)
+		d.observer.inserted(1, d.container.Len())
+
+		return evicted, true, true
+	case OverflowDropNewest:
+		d.observer.dropped(1, d.container.Len())
+
+		return item, true, false
+	case OverflowBlock:
+		for d.full() {
+			// A background context never being done, wait
+			// does not return until the goroutine is signaled.
+			_ = d.observer.wait(context.Background(), &d.producers, &d.RWMutex)
+		}
+
+		push(item)
+		d.observer.inserted(1, d.container.Len())
+
+		return evicted, false, true
+	default:
+		return evicted, false, false
+	}
+}
+
+// ErrCapacityExceeded is returned when unmarshaling more items
+// than a BoundDeque's capacity allows.
+var ErrCapacityExceeded = errors.New("lane: items exceed the BoundDeque's capacity")
+
+// UnmarshalJSON replaces the BoundDeque's items with the ones of the
+// provided JSON array, from front to back. The BoundDeque's capacity is
+// not part of its encoding: if the items exceed it, UnmarshalJSON returns
+// ErrCapacityExceeded and leaves the BoundDeque untouched.
+func (d *BoundDeque[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	return d.replaceBound(items)
+}
+
+// UnmarshalBinary replaces the BoundDeque's items with the ones encoded by
+// MarshalBinary. The BoundDeque's capacity is not part of its encoding: if
+// the items exceed it, UnmarshalBinary returns ErrCapacityExceeded and leaves
+// the BoundDeque untouched.
+func (d *BoundDeque[T]) UnmarshalBinary(data []byte) error {
+	items, err := unmarshalGob[[]T](data)
+	if err != nil {
+		return err
+	}
+
+	return d.replaceBound(items)
+}
+
+// replaceBound replaces the BoundDeque's items with the provided ones,
+// as long as they fit its capacity.
+func (d *BoundDeque[T]) replaceBound(items []T) error {
 	d.Lock()
 	defer d.Unlock()
 
-	if d.Full() {
-		return false
+	if uint(len(items)) > d.capacity {
+		return ErrCapacityExceeded
 	}
 
-	d.container.PushFront(item)
+	d.reset(items)
+
+	return nil
+}
+
+// AppendAll inserts items at the back of the BoundDeque, in order, in an
+// *O(k)* time complexity, k being the number of items. Once the BoundDeque
+// is full, its overflow policy applies to each remaining item, and AppendAll
+// returns the number of inserted items.
+//
+// It acquires the BoundDeque's lock once, and holds it throughout, unless
+// the overflow policy is OverflowBlock: waiting for free slots releases the
+// lock, so that the batch may interleave with other goroutines' operations.
+func (d *BoundDeque[T]) AppendAll(items ...T) uint {
+	return d.insertAll(items, d.container.PushBack, d.container.Front)
+}
+
+// PrependAll inserts items at the BoundDeque's front, in order, in an
+// *O(k)* time complexity, k being the number of items. Once the BoundDeque
+// is full, its overflow policy applies to each remaining item, and PrependAll
+// returns the number of inserted items.
+//
+// It acquires the BoundDeque's lock once, and holds it throughout, unless
+// the overflow policy is OverflowBlock: waiting for free slots releases the
+// lock, so that the batch may interleave with other goroutines' operations.
+func (d *BoundDeque[T]) PrependAll(items ...T) uint {
+	return d.insertAll(items, d.container.PushFront, d.container.Back)
+}
+
+// insertAll inserts items using the push function, applying the
+// BoundDeque's overflow policy once it is full.
+func (d *BoundDeque[T]) insertAll(items []T, push func(T) *Element[T], oldest func() *Element[T]) uint {
+	d.Lock()
+	defer d.Unlock()
+
+	var inserted uint
+	for _, item := range items {
+		if d.full() && d.policy == OverflowReject {
+			break
+		}
+
+		// Consumers are signaled as items get inserted, as the
+		// OverflowBlock policy may release the lock in between.
+		if _, _, ok := d.insert(item, push, oldest); ok {
+			d.consumers.signal()
+			inserted++
+		}
+	}
+
+	return inserted
+}
+
+// AppendWait inserts item at the back of the BoundDeque. If the BoundDeque
+// is full, it blocks until an item is removed from it, or until ctx is done,
+// in which case it returns the context's error.
+//
+// Each removal wakes a single blocked goroutine, in the order they
+// started waiting.
+func (d *BoundDeque[T]) AppendWait(ctx context.Context, item T) error {
+	return d.insertWait(ctx, item, d.container.PushBack)
+}
+
+// PrependWait inserts item at the BoundDeque's front. If the BoundDeque
+// is full, it blocks until an item is removed from it, or until ctx is done,
+// in which case it returns the context's error.
+//
+// Each removal wakes a single blocked goroutine, in the order they
+// started waiting.
+func (d *BoundDeque[T]) PrependWait(ctx context.Context, item T) error {
+	return d.insertWait(ctx, item, d.container.PushFront)
+}
+
+// insertWait inserts item using the push function, waiting for
+// the BoundDeque to have some free capacity if necessary.
+func (d *BoundDeque[T]) insertWait(ctx context.Context, item T, push func(T) *Element[T]) error {
+	d.Lock()
+	defer d.Unlock()
+
+	for d.full() {
+		if err := d.observer.wait(ctx, &d.producers, &d.RWMutex); err != nil {
+			return err
+		}
+	}
+
+	push(item)
+	d.consumers.signal()
+	d.observer.inserted(1, d.container.Len())
 
-	return true
+	return nil
 }
@@ -1,7 +1,11 @@
 package lane
 
 import (
+	"context"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -262,6 +266,192 @@ func BenchmarkDequeShift(b *testing.B) {
 	}
 }
 
+func TestDequePopWait(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		deque     *Deque[int]
+		insert    []int
+		wantValue int
+		wantErr   error
+	}{
+		{
+			desc:      "PopWait on a filled deque returns the back value",
+			deque:     NewDeque([]int{40, 41, 42}...),
+			wantValue: 42,
+		},
+		{
+			desc:      "PopWait on an empty deque returns the inserted value",
+			deque:     NewDeque[int](),
+			insert:    []int{42},
+			wantValue: 42,
+		},
+		{
+			desc:    "PopWait on an empty deque returns the context error",
+			deque:   NewDeque[int](),
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			go func() {
+				for _, item := range tC.insert {
+					tC.deque.Append(item)
+				}
+			}()
+
+			gotValue, gotErr := tC.deque.PopWait(ctx)
+
+			assert.ErrorIs(t, gotErr, tC.wantErr)
+			assert.Equal(t, tC.wantValue, gotValue)
+		})
+	}
+}
+
+func TestDequeShiftWait(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		deque     *Deque[int]
+		insert    []int
+		wantValue int
+		wantErr   error
+	}{
+		{
+			desc:      "ShiftWait on a filled deque returns the front value",
+			deque:     NewDeque([]int{42, 41, 40}...),
+			wantValue: 42,
+		},
+		{
+			desc:      "ShiftWait on an empty deque returns the inserted value",
+			deque:     NewDeque[int](),
+			insert:    []int{42},
+			wantValue: 42,
+		},
+		{
+			desc:    "ShiftWait on an empty deque returns the context error",
+			deque:   NewDeque[int](),
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			go func() {
+				for _, item := range tC.insert {
+					tC.deque.Prepend(item)
+				}
+			}()
+
+			gotValue, gotErr := tC.deque.ShiftWait(ctx)
+
+			assert.ErrorIs(t, gotErr, tC.wantErr)
+			assert.Equal(t, tC.wantValue, gotValue)
+		})
+	}
+}
+
+func TestDequeWaitWakesOneConsumerPerInsertion(t *testing.T) {
+	t.Parallel()
+
+	const consumers = 8
+
+	deque := NewDeque[int]()
+	results := make(chan int, consumers)
+
+	for i := 0; i < consumers; i++ {
+		go func() {
+			item, err := deque.PopWait(context.Background())
+			if err == nil {
+				results <- item
+			}
+		}()
+	}
+
+	for i := 0; i < consumers; i++ {
+		deque.Append(i)
+	}
+
+	got := make([]int, 0, consumers)
+	for i := 0; i < consumers; i++ {
+		got = append(got, <-results)
+	}
+
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, got)
+	assert.True(t, deque.Empty())
+}
+
+func TestDequeWaitCancellationLeavesOtherConsumersWaiting(t *testing.T) {
+	t.Parallel()
+
+	deque := NewDeque[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cancelled := make(chan error)
+	go func() {
+		_, err := deque.ShiftWait(ctx)
+		cancelled <- err
+	}()
+
+	assert.Eventually(t, func() bool { return waiting(deque) == 1 }, time.Second, time.Millisecond)
+
+	result := make(chan int)
+	go func() {
+		item, _ := deque.ShiftWait(context.Background())
+		result <- item
+	}()
+
+	assert.Eventually(t, func() bool { return waiting(deque) == 2 }, time.Second, time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-cancelled, context.Canceled)
+	assert.Equal(t, uint(1), waiting(deque))
+
+	deque.Append(42)
+	assert.Equal(t, 42, <-result)
+}
+
+func BenchmarkDequePopWait(b *testing.B) {
+	b.ReportAllocs()
+
+	deque := NewDeque[int]()
+	ctx := context.Background()
+
+	for i := 0; i < b.N; i++ {
+		deque.Append(i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = deque.PopWait(ctx)
+	}
+}
+
+// waiting returns the number of consumers blocked on deque.
+func waiting[T any](deque *Deque[T]) uint {
+	deque.RLock()
+	defer deque.RUnlock()
+
+	return deque.consumers.waiters.Len()
+}
+
 func TestDequeFirst(t *testing.T) {
 	t.Parallel()
 
@@ -562,6 +752,47 @@ func BenchmarkBoundDequeFull(b *testing.B) {
 	}
 }
 
+func TestBoundDequeConcurrentFullAndAppend(t *testing.T) {
+	t.Parallel()
+
+	const (
+		capacity  = 64
+		producers = 8
+	)
+
+	deque := NewBoundDeque[int](capacity)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < capacity; i++ {
+				deque.Append(i)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < capacity; i++ {
+				if deque.Full() {
+					assert.Equal(t, uint(capacity), deque.Size())
+				}
+
+				assert.Equal(t, uint(capacity), deque.Capacity())
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.True(t, deque.Full())
+	assert.Equal(t, uint(capacity), deque.Size())
+}
+
 // Considering BoundDeque embeds a Deque, no need to cover general
 // cases that are not specifically related to capacity management.
 func TestBoundDequeAppend(t *testing.T) {
@@ -661,3 +892,756 @@ func BenchmarkBoundDeque(b *testing.B) {
 		deque.Prepend(i)
 	}
 }
+
+func TestBoundDequeAppendWait(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		deque     *BoundDeque[int]
+		shift     bool
+		wantErr   error
+		wantItems []int
+	}{
+		{
+			desc:      "AppendWait to BoundDeque with available space inserts value",
+			deque:     NewBoundDeque(2, []int{41}...),
+			wantItems: []int{41, 42},
+		},
+		{
+			desc:      "AppendWait to full BoundDeque inserts value once space is freed",
+			deque:     NewBoundDeque(1, []int{41}...),
+			shift:     true,
+			wantItems: []int{42},
+		},
+		{
+			desc:      "AppendWait to full BoundDeque returns the context error",
+			deque:     NewBoundDeque(1, []int{41}...),
+			wantErr:   context.DeadlineExceeded,
+			wantItems: []int{41},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			if tC.shift {
+				go tC.deque.Shift()
+			}
+
+			gotErr := tC.deque.AppendWait(ctx, 42)
+
+			assert.ErrorIs(t, gotErr, tC.wantErr)
+			assert.Equal(t, tC.wantItems, drain(&tC.deque.Deque))
+		})
+	}
+}
+
+func TestBoundDequePrependWait(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		deque     *BoundDeque[int]
+		pop       bool
+		wantErr   error
+		wantItems []int
+	}{
+		{
+			desc:      "PrependWait to BoundDeque with available space inserts value",
+			deque:     NewBoundDeque(2, []int{43}...),
+			wantItems: []int{42, 43},
+		},
+		{
+			desc:      "PrependWait to full BoundDeque inserts value once space is freed",
+			deque:     NewBoundDeque(1, []int{43}...),
+			pop:       true,
+			wantItems: []int{42},
+		},
+		{
+			desc:      "PrependWait to full BoundDeque returns the context error",
+			deque:     NewBoundDeque(1, []int{43}...),
+			wantErr:   context.DeadlineExceeded,
+			wantItems: []int{43},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			if tC.pop {
+				go tC.deque.Pop()
+			}
+
+			gotErr := tC.deque.PrependWait(ctx, 42)
+
+			assert.ErrorIs(t, gotErr, tC.wantErr)
+			assert.Equal(t, tC.wantItems, drain(&tC.deque.Deque))
+		})
+	}
+}
+
+func TestBoundDequeProducersAndConsumers(t *testing.T) {
+	t.Parallel()
+
+	const (
+		producers = 4
+		items     = 100
+	)
+
+	deque := NewBoundDeque[int](2)
+	ctx := context.Background()
+
+	for p := 0; p < producers; p++ {
+		go func() {
+			for i := 0; i < items; i++ {
+				assert.NoError(t, deque.AppendWait(ctx, i))
+			}
+		}()
+	}
+
+	sum := 0
+	for i := 0; i < producers*items; i++ {
+		item, err := deque.ShiftWait(ctx)
+		assert.NoError(t, err)
+
+		sum += item
+	}
+
+	assert.Equal(t, producers*items*(items-1)/2, sum)
+	assert.True(t, deque.Empty())
+}
+
+// drain shifts every item out of deque, and returns them in order.
+func drain[T any](deque *Deque[T]) []T {
+	var items []T
+
+	for {
+		item, ok := deque.Shift()
+		if !ok {
+			return items
+		}
+
+		items = append(items, item)
+	}
+}
+
+func TestDequeAll(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		deque        *Deque[int]
+		wantAll      []int
+		wantBackward []int
+	}{
+		{
+			desc:  "iterating over an empty deque yields nothing",
+			deque: NewDeque[int](),
+		},
+		{
+			desc:         "iterating over a filled deque yields its items",
+			deque:        NewDeque([]int{40, 41, 42}...),
+			wantAll:      []int{40, 41, 42},
+			wantBackward: []int{42, 41, 40},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tC.wantAll, slices.Collect(tC.deque.All()))
+			assert.Equal(t, tC.wantBackward, slices.Collect(tC.deque.Backward()))
+		})
+	}
+}
+
+func TestDequeAllIsASnapshot(t *testing.T) {
+	t.Parallel()
+
+	deque := NewDeque([]int{40, 41, 42}...)
+
+	var got []int
+	for item := range deque.All() {
+		// Mutating the deque while iterating neither
+		// deadlocks nor affects the iteration.
+		deque.Shift()
+		deque.Append(item + 10)
+
+		got = append(got, item)
+	}
+
+	assert.Equal(t, []int{40, 41, 42}, got)
+	assert.Equal(t, []int{50, 51, 52}, slices.Collect(deque.All()))
+}
+
+func TestDequeBatchInsertion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		insert    func(*Deque[int], ...int)
+		deque     *Deque[int]
+		items     []int
+		wantItems []int
+	}{
+		{
+			desc:      "AppendAll to empty deque inserts values in order",
+			insert:    (*Deque[int]).AppendAll,
+			deque:     NewDeque[int](),
+			items:     []int{40, 41, 42},
+			wantItems: []int{40, 41, 42},
+		},
+		{
+			desc:      "AppendAll inserts values at the back",
+			insert:    (*Deque[int]).AppendAll,
+			deque:     NewDeque([]int{40}...),
+			items:     []int{41, 42},
+			wantItems: []int{40, 41, 42},
+		},
+		{
+			desc:      "PrependAll inserts values at the front as successive Prepend calls",
+			insert:    (*Deque[int]).PrependAll,
+			deque:     NewDeque([]int{42}...),
+			items:     []int{41, 40},
+			wantItems: []int{40, 41, 42},
+		},
+		{
+			desc:      "PrependAll without values leaves the deque untouched",
+			insert:    (*Deque[int]).PrependAll,
+			deque:     NewDeque([]int{42}...),
+			wantItems: []int{42},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tC.insert(tC.deque, tC.items...)
+
+			assert.Equal(t, tC.wantItems, slices.Collect(tC.deque.All()))
+		})
+	}
+}
+
+func BenchmarkDequeAppendAll(b *testing.B) {
+	b.ReportAllocs()
+
+	deque := NewDeque[int]()
+	items := make([]int, 1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		deque.AppendAll(items...)
+	}
+}
+
+func TestDequeBatchRemoval(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc       string
+		remove     func(*Deque[int], uint) []int
+		deque      *Deque[int]
+		n          uint
+		wantValues []int
+		wantItems  []int
+	}{
+		{
+			desc:       "PopN from empty deque",
+			remove:     (*Deque[int]).PopN,
+			deque:      NewDeque[int](),
+			n:          2,
+			wantValues: []int{},
+		},
+		{
+			desc:       "PopN removes and returns values from the back",
+			remove:     (*Deque[int]).PopN,
+			deque:      NewDeque([]int{40, 41, 42}...),
+			n:          2,
+			wantValues: []int{42, 41},
+			wantItems:  []int{40},
+		},
+		{
+			desc:       "ShiftN removes and returns values from the front",
+			remove:     (*Deque[int]).ShiftN,
+			deque:      NewDeque([]int{40, 41, 42}...),
+			n:          2,
+			wantValues: []int{40, 41},
+			wantItems:  []int{42},
+		},
+		{
+			desc:       "ShiftN with n exceeding the deque's size empties it",
+			remove:     (*Deque[int]).ShiftN,
+			deque:      NewDeque([]int{40, 41, 42}...),
+			n:          5,
+			wantValues: []int{40, 41, 42},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			gotValues := tC.remove(tC.deque, tC.n)
+
+			assert.Equal(t, tC.wantValues, gotValues)
+			assert.Equal(t, tC.wantItems, slices.Collect(tC.deque.All()))
+		})
+	}
+}
+
+func BenchmarkDequeShiftN(b *testing.B) {
+	b.ReportAllocs()
+
+	deque := NewDeque[int]()
+	items := make([]int, 1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		deque.AppendAll(items...)
+		b.StartTimer()
+
+		deque.ShiftN(uint(len(items)))
+	}
+}
+
+func TestDequeBatchOperationsWakeWaiters(t *testing.T) {
+	t.Parallel()
+
+	deque := NewBoundDeque[int](2)
+	results := make(chan int)
+
+	for i := 0; i < 2; i++ {
+		go func() {
+			item, _ := deque.ShiftWait(context.Background())
+			results <- item
+		}()
+	}
+
+	assert.Eventually(t, func() bool { return waiting(&deque.Deque) == 2 }, time.Second, time.Millisecond)
+
+	deque.AppendAll(1, 2)
+	assert.ElementsMatch(t, []int{1, 2}, []int{<-results, <-results})
+
+	deque.AppendAll(3, 4)
+
+	inserted := make(chan error)
+	for i := 5; i < 7; i++ {
+		go func() {
+			inserted <- deque.AppendWait(context.Background(), i)
+		}()
+	}
+
+	assert.Equal(t, []int{3, 4}, deque.ShiftN(2))
+	assert.NoError(t, <-inserted)
+	assert.NoError(t, <-inserted)
+	assert.ElementsMatch(t, []int{5, 6}, slices.Collect(deque.All()))
+}
+
+func TestBoundDequeAppendAll(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		insert       func(*BoundDeque[int], ...int) uint
+		deque        *BoundDeque[int]
+		wantInserted uint
+		wantItems    []int
+	}{
+		{
+			desc:         "AppendAll to BoundDeque with enough available space",
+			insert:       (*BoundDeque[int]).AppendAll,
+			deque:        NewBoundDeque(3, []int{40}...),
+			wantInserted: 2,
+			wantItems:    []int{40, 41, 42},
+		},
+		{
+			desc:         "AppendAll to BoundDeque stops once it is full",
+			insert:       (*BoundDeque[int]).AppendAll,
+			deque:        NewBoundDeque(2, []int{40}...),
+			wantInserted: 1,
+			wantItems:    []int{40, 41},
+		},
+		{
+			desc:         "PrependAll to BoundDeque stops once it is full",
+			insert:       (*BoundDeque[int]).PrependAll,
+			deque:        NewBoundDeque(2, []int{40}...),
+			wantInserted: 1,
+			wantItems:    []int{41, 40},
+		},
+		{
+			desc:         "PrependAll to BoundDeque with null capacity",
+			insert:       (*BoundDeque[int]).PrependAll,
+			deque:        NewBoundDeque[int](0),
+			wantInserted: 0,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			gotInserted := tC.insert(tC.deque, 41, 42)
+
+			assert.Equal(t, tC.wantInserted, gotInserted)
+			assert.Equal(t, tC.wantItems, slices.Collect(tC.deque.All()))
+		})
+	}
+}
+
+func TestDequeClear(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc  string
+		deque *Deque[int]
+	}{
+		{
+			desc:  "Clear on an empty deque",
+			deque: NewDeque[int](),
+		},
+		{
+			desc:  "Clear removes every item",
+			deque: NewDeque([]int{40, 41, 42}...),
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tC.deque.Clear()
+
+			assert.True(t, tC.deque.Empty())
+			assert.Equal(t, []int{}, tC.deque.ToSlice())
+
+			// The deque remains usable.
+			tC.deque.Append(42)
+			assert.Equal(t, []int{42}, tC.deque.ToSlice())
+		})
+	}
+}
+
+func TestDequeCloneAndToSlice(t *testing.T) {
+	t.Parallel()
+
+	deque := NewDeque([]int{40, 41, 42}...)
+	clone := deque.Clone()
+
+	clone.Append(43)
+	deque.Shift()
+
+	assert.Equal(t, []int{41, 42}, deque.ToSlice())
+	assert.Equal(t, []int{40, 41, 42, 43}, clone.ToSlice())
+}
+
+func TestBoundDequeClear(t *testing.T) {
+	t.Parallel()
+
+	deque := NewBoundDeque(1, []int{42}...)
+
+	inserted := make(chan error)
+	go func() {
+		inserted <- deque.AppendWait(context.Background(), 43)
+	}()
+
+	assert.Eventually(t, func() bool { return producersWaiting(deque) == 1 }, time.Second, time.Millisecond)
+
+	// Clearing the BoundDeque frees up capacity for blocked producers.
+	deque.Clear()
+
+	assert.NoError(t, <-inserted)
+	assert.Equal(t, []int{43}, deque.ToSlice())
+}
+
+func TestBoundDequeClone(t *testing.T) {
+	t.Parallel()
+
+	deque := NewBoundDeque(2, []int{41}...)
+	clone := deque.Clone()
+
+	assert.True(t, clone.Append(42))
+	assert.False(t, clone.Append(43))
+	assert.Equal(t, uint(2), clone.Capacity())
+	assert.Equal(t, []int{41, 42}, clone.ToSlice())
+	assert.Equal(t, []int{41}, deque.ToSlice())
+}
+
+// producersWaiting returns the number of producers blocked on deque.
+func producersWaiting[T any](deque *BoundDeque[T]) uint {
+	deque.RLock()
+	defer deque.RUnlock()
+
+	return deque.producers.waiters.Len()
+}
+
+func TestBoundDequeOverflowPolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		insert       func(*BoundDeque[int], int) (int, bool, bool)
+		deque        *BoundDeque[int]
+		wantEvicted  int
+		wantDropped  bool
+		wantInserted bool
+		wantItems    []int
+	}{
+		{
+			desc:         "AppendEvict to BoundDeque with available space",
+			insert:       (*BoundDeque[int]).AppendEvict,
+			deque:        NewBoundDequeWithPolicy(3, OverflowDropOldest, []int{40, 41}...),
+			wantDropped:  false,
+			wantInserted: true,
+			wantItems:    []int{40, 41, 42},
+		},
+		{
+			desc:         "AppendEvict to full BoundDeque with reject policy",
+			insert:       (*BoundDeque[int]).AppendEvict,
+			deque:        NewBoundDequeWithPolicy(2, OverflowReject, []int{40, 41}...),
+			wantDropped:  false,
+			wantInserted: false,
+			wantItems:    []int{40, 41},
+		},
+		{
+			desc:         "AppendEvict to full BoundDeque with drop oldest policy",
+			insert:       (*BoundDeque[int]).AppendEvict,
+			deque:        NewBoundDequeWithPolicy(2, OverflowDropOldest, []int{40, 41}...),
+			wantEvicted:  40,
+			wantDropped:  true,
+			wantInserted: true,
+			wantItems:    []int{41, 42},
+		},
+		{
+			desc:         "PrependEvict to full BoundDeque with drop oldest policy",
+			insert:       (*BoundDeque[int]).PrependEvict,
+			deque:        NewBoundDequeWithPolicy(2, OverflowDropOldest, []int{40, 41}...),
+			wantEvicted:  41,
+			wantDropped:  true,
+			wantInserted: true,
+			wantItems:    []int{42, 40},
+		},
+		{
+			desc:         "AppendEvict to full BoundDeque with drop newest policy",
+			insert:       (*BoundDeque[int]).AppendEvict,
+			deque:        NewBoundDequeWithPolicy(2, OverflowDropNewest, []int{40, 41}...),
+			wantEvicted:  42,
+			wantDropped:  true,
+			wantInserted: false,
+			wantItems:    []int{40, 41},
+		},
+		{
+			desc:         "AppendEvict to BoundDeque with null capacity and drop oldest policy",
+			insert:       (*BoundDeque[int]).AppendEvict,
+			deque:        NewBoundDequeWithPolicy[int](0, OverflowDropOldest),
+			wantEvicted:  42,
+			wantDropped:  true,
+			wantInserted: false,
+			wantItems:    []int{},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			gotEvicted, gotDropped, gotInserted := tC.insert(tC.deque, 42)
+
+			assert.Equal(t, tC.wantEvicted, gotEvicted)
+			assert.Equal(t, tC.wantDropped, gotDropped)
+			assert.Equal(t, tC.wantInserted, gotInserted)
+			assert.Equal(t, tC.wantItems, tC.deque.ToSlice())
+		})
+	}
+}
+
+func TestBoundDequeOverflowPolicyAppendAll(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		deque        *BoundDeque[int]
+		wantInserted uint
+		wantItems    []int
+	}{
+		{
+			desc:         "AppendAll to BoundDeque with drop oldest policy",
+			deque:        NewBoundDequeWithPolicy(2, OverflowDropOldest, []int{40}...),
+			wantInserted: 3,
+			wantItems:    []int{42, 43},
+		},
+		{
+			desc:         "AppendAll to BoundDeque with drop newest policy",
+			deque:        NewBoundDequeWithPolicy(2, OverflowDropNewest, []int{40}...),
+			wantInserted: 1,
+			wantItems:    []int{40, 41},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			gotInserted := tC.deque.AppendAll(41, 42, 43)
+
+			assert.Equal(t, tC.wantInserted, gotInserted)
+			assert.Equal(t, tC.wantItems, tC.deque.ToSlice())
+		})
+	}
+}
+
+func TestBoundDequeOverflowBlock(t *testing.T) {
+	t.Parallel()
+
+	deque := NewBoundDequeWithPolicy(1, OverflowBlock, []int{40}...)
+
+	inserted := make(chan uint)
+	go func() {
+		inserted <- deque.AppendAll(41, 42)
+	}()
+
+	for _, want := range []int{40, 41, 42} {
+		got, err := deque.ShiftWait(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	assert.Equal(t, uint(2), <-inserted)
+	assert.True(t, deque.Empty())
+}
+
+func TestBoundDequeSetCapacity(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		capacity     uint
+		policy       ShrinkPolicy
+		wantRemoved  []int
+		wantOk       bool
+		wantCapacity uint
+		wantItems    []int
+	}{
+		{
+			desc:         "SetCapacity growing the capacity",
+			capacity:     5,
+			policy:       ShrinkReject,
+			wantOk:       true,
+			wantCapacity: 5,
+			wantItems:    []int{40, 41, 42},
+		},
+		{
+			desc:         "SetCapacity shrinking the capacity down to the size",
+			capacity:     3,
+			policy:       ShrinkReject,
+			wantOk:       true,
+			wantCapacity: 3,
+			wantItems:    []int{40, 41, 42},
+		},
+		{
+			desc:         "SetCapacity below the size with reject policy",
+			capacity:     1,
+			policy:       ShrinkReject,
+			wantOk:       false,
+			wantCapacity: 4,
+			wantItems:    []int{40, 41, 42},
+		},
+		{
+			desc:         "SetCapacity below the size with truncate front policy",
+			capacity:     1,
+			policy:       ShrinkTruncateFront,
+			wantRemoved:  []int{40, 41},
+			wantOk:       true,
+			wantCapacity: 1,
+			wantItems:    []int{42},
+		},
+		{
+			desc:         "SetCapacity below the size with truncate back policy",
+			capacity:     1,
+			policy:       ShrinkTruncateBack,
+			wantRemoved:  []int{41, 42},
+			wantOk:       true,
+			wantCapacity: 1,
+			wantItems:    []int{40},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			deque := NewBoundDeque(4, []int{40, 41, 42}...)
+
+			gotRemoved, gotOk := deque.SetCapacity(tC.capacity, tC.policy)
+
+			assert.Equal(t, tC.wantRemoved, gotRemoved)
+			assert.Equal(t, tC.wantOk, gotOk)
+			assert.Equal(t, tC.wantCapacity, deque.Capacity())
+			assert.Equal(t, tC.wantItems, deque.ToSlice())
+		})
+	}
+}
+
+func TestBoundDequeSetCapacityWakesProducers(t *testing.T) {
+	t.Parallel()
+
+	deque := NewBoundDeque(1, []int{40}...)
+
+	inserted := make(chan error)
+	for _, item := range []int{41, 42, 43} {
+		item := item
+
+		go func() {
+			inserted <- deque.AppendWait(context.Background(), item)
+		}()
+	}
+
+	assert.Eventually(t, func() bool { return producersWaiting(deque) == 3 }, time.Second, time.Millisecond)
+
+	// Growing the capacity by two slots wakes two producers.
+	_, ok := deque.SetCapacity(3, ShrinkReject)
+	assert.True(t, ok)
+
+	assert.NoError(t, <-inserted)
+	assert.NoError(t, <-inserted)
+	assert.Equal(t, uint(1), producersWaiting(deque))
+	assert.True(t, deque.Full())
+
+	// Removing an item frees up capacity for the last one.
+	deque.Shift()
+	assert.NoError(t, <-inserted)
+	assert.Equal(t, uint(3), deque.Size())
+}
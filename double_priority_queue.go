@@ -0,0 +1,302 @@
+package lane
+
+import (
+	"math/bits"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+// DoublePriorityQueue is a double-ended priority queue, serving both its
+// lowest and highest priority items.
+//
+// It is built upon a min-max heap: a binary heap whose even levels are
+// ordered as a min heap, and odd levels as a max heap. Pushing or popping
+// items from either end thus happens in *O(log n)* time complexity, while
+// peeking at them happens in *O(1)*.
+//
+// Every operation on a DoublePriorityQueue is goroutine-safe.
+type DoublePriorityQueue[T any, P any] struct {
+	sync.RWMutex
+
+	// items holds the min-max heap, rooted at index 0.
+	items []priorityQueueEntry[T, P]
+
+	// less reports whether the lhs priority is lower than the rhs one.
+	less func(lhs, rhs P) bool
+}
+
+// NewDoublePriorityQueue produces a new DoublePriorityQueue instance,
+// ordering priorities using the less function, which reports whether
+// `lhs` is lower than `rhs`.
+func NewDoublePriorityQueue[T any, P any](less func(lhs, rhs P) bool) *DoublePriorityQueue[T, P] {
+	return &DoublePriorityQueue[T, P]{
+		less: less,
+	}
+}
+
+// NewOrderedDoublePriorityQueue produces a new DoublePriorityQueue instance,
+// ordering priorities using their natural order.
+func NewOrderedDoublePriorityQueue[T any, P constraints.Ordered]() *DoublePriorityQueue[T, P] {
+	return NewDoublePriorityQueue[T](func(lhs, rhs P) bool {
+		return lhs < rhs
+	})
+}
+
+// Push inserts the value in the DoublePriorityQueue with the provided
+// priority in at most *O(log n)* time complexity.
+func (pq *DoublePriorityQueue[T, P]) Push(value T, priority P) {
+	pq.Lock()
+	defer pq.Unlock()
+
+	pq.push(value, priority)
+}
+
+// PopMin removes and returns the lowest priority item of the
+// DoublePriorityQueue in at most *O(log n)* time complexity.
+func (pq *DoublePriorityQueue[T, P]) PopMin() (value T, priority P, ok bool) {
+	pq.Lock()
+	defer pq.Unlock()
+
+	k, ok := pq.minIndex()
+	if !ok {
+		return value, priority, false
+	}
+
+	entry := pq.removeAt(k)
+
+	return entry.Value, entry.Priority, true
+}
+
+// PopMax removes and returns the highest priority item of the
+// DoublePriorityQueue in at most *O(log n)* time complexity.
+func (pq *DoublePriorityQueue[T, P]) PopMax() (value T, priority P, ok bool) {
+	pq.Lock()
+	defer pq.Unlock()
+
+	k, ok := pq.maxIndex()
+	if !ok {
+		return value, priority, false
+	}
+
+	entry := pq.removeAt(k)
+
+	return entry.Value, entry.Priority, true
+}
+
+// PeekMin returns the lowest priority item of the DoublePriorityQueue
+// in *O(1)* time complexity.
+func (pq *DoublePriorityQueue[T, P]) PeekMin() (value T, priority P, ok bool) {
+	pq.RLock()
+	defer pq.RUnlock()
+
+	k, ok := pq.minIndex()
+	if !ok {
+		return value, priority, false
+	}
+
+	return pq.items[k].Value, pq.items[k].Priority, true
+}
+
+// PeekMax returns the highest priority item of the DoublePriorityQueue
+// in *O(1)* time complexity.
+func (pq *DoublePriorityQueue[T, P]) PeekMax() (value T, priority P, ok bool) {
+	pq.RLock()
+	defer pq.RUnlock()
+
+	k, ok := pq.maxIndex()
+	if !ok {
+		return value, priority, false
+	}
+
+	return pq.items[k].Value, pq.items[k].Priority, true
+}
+
+// Size returns the number of items present in the DoublePriorityQueue.
+func (pq *DoublePriorityQueue[T, P]) Size() uint {
+	pq.RLock()
+	defer pq.RUnlock()
+
+	return uint(len(pq.items))
+}
+
+// Empty returns whether the DoublePriorityQueue is empty.
+func (pq *DoublePriorityQueue[T, P]) Empty() bool {
+	pq.RLock()
+	defer pq.RUnlock()
+
+	return len(pq.items) == 0
+}
+
+// push inserts the value with the provided priority, and restores
+// the heap ordering.
+func (pq *DoublePriorityQueue[T, P]) push(value T, priority P) {
+	pq.items = append(pq.items, priorityQueueEntry[T, P]{Value: value, Priority: priority})
+	pq.bubbleUp(len(pq.items) - 1)
+}
+
+// minIndex returns the index of the lowest priority item,
+// and false if the heap is empty.
+func (pq *DoublePriorityQueue[T, P]) minIndex() (int, bool) {
+	return 0, len(pq.items) > 0
+}
+
+// maxIndex returns the index of the highest priority item, which is
+// one of the root's children, and false if the heap is empty.
+func (pq *DoublePriorityQueue[T, P]) maxIndex() (int, bool) {
+	switch len(pq.items) {
+	case 0:
+		return 0, false
+	case 1:
+		return 0, true
+	case 2:
+		return 1, true
+	}
+
+	if pq.lessAt(1, 2) {
+		return 2, true
+	}
+
+	return 1, true
+}
+
+// removeAt removes and returns the item at index k, which must either be
+// the lowest or highest priority one, and restores the heap ordering.
+func (pq *DoublePriorityQueue[T, P]) removeAt(k int) priorityQueueEntry[T, P] {
+	entry := pq.items[k]
+	last := len(pq.items) - 1
+
+	pq.items[k] = pq.items[last]
+	pq.items[last] = priorityQueueEntry[T, P]{} // avoid memory leaks
+	pq.items = pq.items[:last]
+
+	if k < last {
+		pq.trickleDown(k)
+	}
+
+	return entry
+}
+
+// bubbleUp moves the item at index k up the heap, until it is
+// correctly ordered with its ancestors.
+func (pq *DoublePriorityQueue[T, P]) bubbleUp(k int) {
+	if k == 0 {
+		return
+	}
+
+	parent := (k - 1) / 2
+
+	switch {
+	case isMinLevel(k) && pq.lessAt(parent, k):
+		pq.exch(k, parent)
+		pq.bubbleUpLevels(parent, pq.greaterAt)
+	case isMinLevel(k):
+		pq.bubbleUpLevels(k, pq.lessAt)
+	case pq.lessAt(k, parent):
+		pq.exch(k, parent)
+		pq.bubbleUpLevels(parent, pq.lessAt)
+	default:
+		pq.bubbleUpLevels(k, pq.greaterAt)
+	}
+}
+
+// bubbleUpLevels moves the item at index k up the levels of its kind,
+// min or max, for as long as it precedes its grandparent according to
+// the precedes function.
+func (pq *DoublePriorityQueue[T, P]) bubbleUpLevels(k int, precedes func(lhs, rhs int) bool) {
+	for k > 2 {
+		grandparent := ((k-1)/2 - 1) / 2
+		if !precedes(k, grandparent) {
+			return
+		}
+
+		pq.exch(k, grandparent)
+		k = grandparent
+	}
+}
+
+// trickleDown moves the item at index k down the heap, until it is
+// correctly ordered with its descendants.
+func (pq *DoublePriorityQueue[T, P]) trickleDown(k int) {
+	if isMinLevel(k) {
+		pq.trickleDownLevels(k, pq.lessAt)
+	} else {
+		pq.trickleDownLevels(k, pq.greaterAt)
+	}
+}
+
+// trickleDownLevels moves the item at index k down the levels of its
+// kind, min or max, for as long as one of its children or grandchildren
+// precedes it according to the precedes function.
+func (pq *DoublePriorityQueue[T, P]) trickleDownLevels(k int, precedes func(lhs, rhs int) bool) {
+	for {
+		m, grandchild := pq.precedingDescendant(k, precedes)
+		if m < 0 || !precedes(m, k) {
+			return
+		}
+
+		pq.exch(m, k)
+
+		if !grandchild {
+			return
+		}
+
+		if parent := (m - 1) / 2; precedes(parent, m) {
+			pq.exch(m, parent)
+		}
+
+		k = m
+	}
+}
+
+// precedingDescendant returns the index of the child or grandchild of the
+// item at index k which precedes the others according to the precedes
+// function, and whether it is a grandchild. It returns -1 if the item at
+// index k has no children.
+func (pq *DoublePriorityQueue[T, P]) precedingDescendant(k int, precedes func(lhs, rhs int) bool) (int, bool) {
+	m, grandchild := -1, false
+
+	for _, child := range [2]int{2*k + 1, 2*k + 2} {
+		if child >= len(pq.items) {
+			break
+		}
+
+		if m < 0 || precedes(child, m) {
+			m, grandchild = child, false
+		}
+
+		for _, descendant := range [2]int{2*child + 1, 2*child + 2} {
+			if descendant >= len(pq.items) {
+				break
+			}
+
+			if precedes(descendant, m) {
+				m, grandchild = descendant, true
+			}
+		}
+	}
+
+	return m, grandchild
+}
+
+// lessAt reports whether the priority of the item at index lhs
+// is lower than the one of the item at index rhs.
+func (pq *DoublePriorityQueue[T, P]) lessAt(lhs, rhs int) bool {
+	return pq.less(pq.items[lhs].Priority, pq.items[rhs].Priority)
+}
+
+// greaterAt reports whether the priority of the item at index lhs
+// is higher than the one of the item at index rhs.
+func (pq *DoublePriorityQueue[T, P]) greaterAt(lhs, rhs int) bool {
+	return pq.less(pq.items[rhs].Priority, pq.items[lhs].Priority)
+}
+
+func (pq *DoublePriorityQueue[T, P]) exch(lhs, rhs int) {
+	pq.items[lhs], pq.items[rhs] = pq.items[rhs], pq.items[lhs]
+}
+
+// isMinLevel returns whether index k of a min-max heap belongs
+// to one of its min levels, the root's level being one.
+func isMinLevel(k int) bool {
+	return bits.Len(uint(k+1))%2 == 1
+}
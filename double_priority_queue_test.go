@@ -0,0 +1,224 @@
+package lane
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoublePriorityQueuePopMin(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		priorities   []int
+		wantPriority int
+		wantOk       bool
+		wantSize     uint
+	}{
+		{
+			desc:   "PopMin on an empty DoublePriorityQueue",
+			wantOk: false,
+		},
+		{
+			desc:         "PopMin on a single item DoublePriorityQueue",
+			priorities:   []int{42},
+			wantPriority: 42,
+			wantOk:       true,
+			wantSize:     0,
+		},
+		{
+			desc:         "PopMin on a filled DoublePriorityQueue",
+			priorities:   []int{41, 43, 40, 44, 42},
+			wantPriority: 40,
+			wantOk:       true,
+			wantSize:     4,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			pqueue := NewOrderedDoublePriorityQueue[int, int]()
+			for _, priority := range tC.priorities {
+				pqueue.Push(priority, priority)
+			}
+
+			gotValue, gotPriority, gotOk := pqueue.PopMin()
+
+			assert.Equal(t, tC.wantPriority, gotValue)
+			assert.Equal(t, tC.wantPriority, gotPriority)
+			assert.Equal(t, tC.wantOk, gotOk)
+			assert.Equal(t, tC.wantSize, pqueue.Size())
+		})
+	}
+}
+
+func TestDoublePriorityQueuePopMax(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		priorities   []int
+		wantPriority int
+		wantOk       bool
+		wantSize     uint
+	}{
+		{
+			desc:   "PopMax on an empty DoublePriorityQueue",
+			wantOk: false,
+		},
+		{
+			desc:         "PopMax on a single item DoublePriorityQueue",
+			priorities:   []int{42},
+			wantPriority: 42,
+			wantOk:       true,
+			wantSize:     0,
+		},
+		{
+			desc:         "PopMax on a two items DoublePriorityQueue",
+			priorities:   []int{42, 41},
+			wantPriority: 42,
+			wantOk:       true,
+			wantSize:     1,
+		},
+		{
+			desc:         "PopMax on a filled DoublePriorityQueue",
+			priorities:   []int{41, 43, 40, 44, 42},
+			wantPriority: 44,
+			wantOk:       true,
+			wantSize:     4,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			pqueue := NewOrderedDoublePriorityQueue[int, int]()
+			for _, priority := range tC.priorities {
+				pqueue.Push(priority, priority)
+			}
+
+			gotValue, gotPriority, gotOk := pqueue.PopMax()
+
+			assert.Equal(t, tC.wantPriority, gotValue)
+			assert.Equal(t, tC.wantPriority, gotPriority)
+			assert.Equal(t, tC.wantOk, gotOk)
+			assert.Equal(t, tC.wantSize, pqueue.Size())
+		})
+	}
+}
+
+func TestDoublePriorityQueuePeek(t *testing.T) {
+	t.Parallel()
+
+	pqueue := NewDoublePriorityQueue[string](func(lhs, rhs int) bool {
+		return lhs < rhs
+	})
+
+	_, _, gotOk := pqueue.PeekMin()
+	assert.False(t, gotOk)
+
+	_, _, gotOk = pqueue.PeekMax()
+	assert.False(t, gotOk)
+
+	pqueue.Push("b", 2)
+	pqueue.Push("c", 3)
+	pqueue.Push("a", 1)
+
+	gotValue, gotPriority, gotOk := pqueue.PeekMin()
+	assert.Equal(t, "a", gotValue)
+	assert.Equal(t, 1, gotPriority)
+	assert.True(t, gotOk)
+
+	gotValue, gotPriority, gotOk = pqueue.PeekMax()
+	assert.Equal(t, "c", gotValue)
+	assert.Equal(t, 3, gotPriority)
+	assert.True(t, gotOk)
+
+	assert.Equal(t, uint(3), pqueue.Size())
+	assert.False(t, pqueue.Empty())
+}
+
+func TestDoublePriorityQueueOrdering(t *testing.T) {
+	t.Parallel()
+
+	// Interleaving pushes and pops from both ends, the DoublePriorityQueue
+	// must behave like a sorted slice.
+	random := rand.New(rand.NewPCG(1, 2))
+	pqueue := NewOrderedDoublePriorityQueue[int, int]()
+
+	var want []int
+	for i := 0; i < 10000; i++ {
+		switch random.IntN(4) {
+		case 0, 1:
+			priority := random.IntN(100)
+			pqueue.Push(priority, priority)
+
+			want = append(want, priority)
+			slices.Sort(want)
+		case 2:
+			_, gotPriority, gotOk := pqueue.PopMin()
+			if assert.Equal(t, len(want) > 0, gotOk) && gotOk {
+				assert.Equal(t, want[0], gotPriority)
+				want = want[1:]
+			}
+		case 3:
+			_, gotPriority, gotOk := pqueue.PopMax()
+			if assert.Equal(t, len(want) > 0, gotOk) && gotOk {
+				assert.Equal(t, want[len(want)-1], gotPriority)
+				want = want[:len(want)-1]
+			}
+		}
+
+		assert.Equal(t, uint(len(want)), pqueue.Size())
+	}
+}
+
+func BenchmarkDoublePriorityQueuePush(b *testing.B) {
+	b.ReportAllocs()
+
+	pqueue := NewOrderedDoublePriorityQueue[int, int]()
+
+	for i := 0; i < b.N; i++ {
+		pqueue.Push(i, i)
+	}
+}
+
+func BenchmarkDoublePriorityQueuePopMin(b *testing.B) {
+	b.ReportAllocs()
+
+	pqueue := NewOrderedDoublePriorityQueue[int, int]()
+
+	for i := 0; i < b.N; i++ {
+		pqueue.Push(i, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pqueue.PopMin()
+	}
+}
+
+func BenchmarkDoublePriorityQueuePopMax(b *testing.B) {
+	b.ReportAllocs()
+
+	pqueue := NewOrderedDoublePriorityQueue[int, int]()
+
+	for i := 0; i < b.N; i++ {
+		pqueue.Push(i, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pqueue.PopMax()
+	}
+}
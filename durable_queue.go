@@ -0,0 +1,542 @@
+package lane
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrClosed is returned by operations on a DurableQueue which was closed.
+var ErrClosed = errors.New("lane: durable queue is closed")
+
+// Codec serializes the items of a DurableQueue.
+type Codec[T any] interface {
+	// Encode returns the serialized form of item.
+	Encode(item T) ([]byte, error)
+
+	// Decode returns the item serialized in data.
+	Decode(data []byte) (T, error)
+}
+
+// JSONCodec is a Codec serializing items as JSON documents.
+type JSONCodec[T any] struct{}
+
+// Encode returns the JSON encoding of item.
+func (JSONCodec[T]) Encode(item T) ([]byte, error) {
+	return json.Marshal(item)
+}
+
+// Decode returns the item JSON encoded in data.
+func (JSONCodec[T]) Decode(data []byte) (item T, err error) {
+	err = json.Unmarshal(data, &item)
+	return item, err
+}
+
+// SyncPolicy defines when a DurableQueue flushes its writes to stable storage.
+type SyncPolicy int
+
+const (
+	// SyncAlways flushes every write to stable storage before the
+	// operation returns. Acknowledged operations survive a machine crash.
+	SyncAlways SyncPolicy = iota
+
+	// SyncNever leaves flushing writes to the operating system, or to
+	// explicit calls to Sync. Acknowledged operations survive a process
+	// crash, but might be lost on a machine crash.
+	SyncNever
+)
+
+// DurableQueueOption configures a DurableQueue when it is opened.
+type DurableQueueOption func(*durableQueueOptions)
+
+// durableQueueOptions holds the configuration of a DurableQueue.
+type durableQueueOptions struct {
+	sync                SyncPolicy
+	compactionThreshold uint
+}
+
+// WithSyncPolicy defines when a DurableQueue flushes its writes to
+// stable storage. It defaults to SyncAlways.
+func WithSyncPolicy(policy SyncPolicy) DurableQueueOption {
+	return func(options *durableQueueOptions) {
+		options.sync = policy
+	}
+}
+
+// WithCompactionThreshold defines the minimum number of dequeued items a
+// DurableQueue's log holds before it is compacted. It defaults to 1024.
+//
+// Compaction also requires dequeued items to outnumber the items left in
+// the queue, so that its cost is amortized over the dequeue operations.
+func WithCompactionThreshold(threshold uint) DurableQueueOption {
+	return func(options *durableQueueOptions) {
+		options.compactionThreshold = threshold
+	}
+}
+
+const (
+	// durableQueueLog is the name of the file holding a DurableQueue's log.
+	durableQueueLog = "queue.log"
+
+	// durableQueueCompactedLog is the name of the file a DurableQueue's log is
+	// compacted into, before it replaces the log.
+	durableQueueCompactedLog = "queue.log.compact"
+)
+
+// Operations recorded in a DurableQueue's log.
+const (
+	opEnqueue byte = iota + 1
+	opDequeue
+)
+
+// recordHeaderSize is the size of a log record's header: its operation
+// byte, followed by the size of its payload as a big endian uint32.
+const recordHeaderSize = 5
+
+// recordChecksumSize is the size of the CRC-32 checksum closing a log record.
+const recordChecksumSize = 4
+
+// DurableQueue is a First In First Out data structure implementation,
+// persisting its content in a local directory.
+//
+// Every operation is recorded in an append-only log before being applied, so
+// that the queue's content is recovered when it is opened again, after a
+// restart or a crash. Each log record is checksummed: a record torn by a crash
+// is detected, and discarded along with anything which follows it. The log is
+// periodically compacted, dropping the records of dequeued items.
+//
+// Its API focuses on the following core functionalities: Enqueue, Dequeue,
+// Head, Size, Empty. Enqueue and Dequeue have a time complexity of *O(1)*,
+// amortized over compactions, on top of the cost of writing to the log.
+//
+// If a write leaves the log in an unknown state, such as when flushing it to
+// stable storage fails, the DurableQueue is closed, and every later operation
+// returns an error wrapping both ErrClosed and the write's failure. Opening the
+// directory again recovers the operations acknowledged before it.
+//
+// Every operation over an opened DurableQueue is goroutine-safe. A directory
+// must not be opened by more than one DurableQueue at a time.
+type DurableQueue[T any] struct {
+	sync.Mutex
+
+	// The underlying in-memory storage container.
+	container *ring[T]
+
+	dir     string
+	log     durableLog
+	codec   Codec[T]
+	options durableQueueOptions
+
+	// failure is the error which closed the DurableQueue
+	// after a failed write, if any.
+	failure error
+
+	// dequeued is the number of items dequeued since the log was
+	// last compacted, whose records are still held by the log.
+	dequeued uint
+}
+
+// OpenDurableQueue opens the DurableQueue persisted in dir, creating the
+// directory if necessary, and recovers its content. Items are serialized
+// using codec.
+func OpenDurableQueue[T any](dir string, codec Codec[T], options ...DurableQueueOption) (*DurableQueue[T], error) {
+	opts := durableQueueOptions{sync: SyncAlways, compactionThreshold: 1024}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("lane: creating durable queue directory: %w", err)
+	}
+
+	// A leftover compacted log means a compaction was interrupted
+	// before it replaced the log, which is thus still valid.
+	if err := os.Remove(filepath.Join(dir, durableQueueCompactedLog)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("lane: removing interrupted compaction: %w", err)
+	}
+
+	log, err := os.OpenFile(filepath.Join(dir, durableQueueLog), os.O_RDWR|os.O_CREATE, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("lane: opening durable queue log: %w", err)
+	}
+
+	q := &DurableQueue[T]{
+		container: newRing[T](),
+		dir:       dir,
+		log:       log,
+		codec:     codec,
+		options:   opts,
+	}
+
+	if err := q.recover(); err != nil {
+		log.Close()
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// Enqueue adds an item at the back of the DurableQueue, once
+// recorded in its log.
+func (q *DurableQueue[T]) Enqueue(item T) error {
+	payload, err := q.codec.Encode(item)
+	if err != nil {
+		return fmt.Errorf("lane: encoding item: %w", err)
+	}
+
+	q.Lock()
+	defer q.Unlock()
+
+	if err := q.usable(); err != nil {
+		return err
+	}
+
+	if err := q.write(appendRecord(nil, opEnqueue, payload)); err != nil {
+		return err
+	}
+
+	q.container.pushBack(item)
+
+	return nil
+}
+
+// Dequeue removes and returns the DurableQueue's front item, once its
+// removal is recorded in its log. If the DurableQueue is empty, ok is false.
+//
+// Dequeue may compact the log as well. A failed compaction is not reported,
+// as the item is dequeued for good by then: the log is left as it was, and
+// compacting it is retried on the next Dequeue. Call Compact to handle its
+// failures explicitly.
+func (q *DurableQueue[T]) Dequeue() (item T, ok bool, err error) {
+	q.Lock()
+	defer q.Unlock()
+
+	if err := q.usable(); err != nil {
+		return item, false, err
+	}
+
+	if q.container.Len() == 0 {
+		return item, false, nil
+	}
+
+	if err := q.write(appendRecord(nil, opDequeue, nil)); err != nil {
+		return item, false, err
+	}
+
+	item, _ = q.container.popFront()
+	q.dequeued++
+
+	if q.dequeued >= q.options.compactionThreshold && q.dequeued > q.container.Len() {
+		// The item is dequeued for good at this point: a failed
+		// compaction leaves the log as it was, and dequeued above
+		// the threshold, so that the next Dequeue retries it.
+		_ = q.compact()
+	}
+
+	return item, true, nil
+}
+
+// Head returns the DurableQueue's front item.
+func (q *DurableQueue[T]) Head() (item T, ok bool) {
+	q.Lock()
+	defer q.Unlock()
+
+	return q.container.front()
+}
+
+// Size returns the size of the DurableQueue.
+func (q *DurableQueue[T]) Size() uint {
+	q.Lock()
+	defer q.Unlock()
+
+	return q.container.Len()
+}
+
+// Empty returns whether the DurableQueue is empty.
+func (q *DurableQueue[T]) Empty() bool {
+	q.Lock()
+	defer q.Unlock()
+
+	return q.container.Len() == 0
+}
+
+// Compact rewrites the DurableQueue's log so that it only holds the items
+// left in the queue.
+func (q *DurableQueue[T]) Compact() error {
+	q.Lock()
+	defer q.Unlock()
+
+	if err := q.usable(); err != nil {
+		return err
+	}
+
+	return q.compact()
+}
+
+// Sync flushes the DurableQueue's log to stable storage.
+func (q *DurableQueue[T]) Sync() error {
+	q.Lock()
+	defer q.Unlock()
+
+	if err := q.usable(); err != nil {
+		return err
+	}
+
+	return q.log.Sync()
+}
+
+// Close flushes the DurableQueue's log to stable storage, and closes it.
+func (q *DurableQueue[T]) Close() error {
+	q.Lock()
+	defer q.Unlock()
+
+	if err := q.usable(); err != nil {
+		return err
+	}
+
+	err := q.log.Sync()
+	if closeErr := q.log.Close(); err == nil {
+		err = closeErr
+	}
+
+	q.log = nil
+
+	return err
+}
+
+// recover replays the log's records, and truncates the log
+// after the last valid one.
+func (q *DurableQueue[T]) recover() error {
+	info, err := q.log.Stat()
+	if err != nil {
+		return fmt.Errorf("lane: reading durable queue log: %w", err)
+	}
+
+	reader := bufio.NewReader(q.log)
+
+	var valid int64
+
+	for {
+		op, payload, size, err := readRecord(reader, info.Size()-valid)
+		if err != nil {
+			// Anything which follows the last valid record
+			// is the remainder of an interrupted write.
+			break
+		}
+
+		switch op {
+		case opEnqueue:
+			item, err := q.codec.Decode(payload)
+			if err != nil {
+				return fmt.Errorf("lane: decoding item: %w", err)
+			}
+
+			q.container.pushBack(item)
+		case opDequeue:
+			q.container.popFront()
+			q.dequeued++
+		}
+
+		valid += size
+	}
+
+	if err := q.log.Truncate(valid); err != nil {
+		return fmt.Errorf("lane: truncating durable queue log: %w", err)
+	}
+
+	if _, err := q.log.Seek(valid, io.SeekStart); err != nil {
+		return fmt.Errorf("lane: seeking durable queue log: %w", err)
+	}
+
+	return nil
+}
+
+// write appends record to the log, and flushes it according
+// to the sync policy.
+func (q *DurableQueue[T]) write(record []byte) error {
+	offset, err := q.log.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("lane: seeking durable queue log: %w", err)
+	}
+
+	if _, err := q.log.Write(record); err != nil {
+		err = fmt.Errorf("lane: writing durable queue log: %w", err)
+
+		// Discard a partially written record, so that it does not
+		// shadow the records written after it on recovery.
+		if !q.discard(offset) {
+			return q.poison(err)
+		}
+
+		return err
+	}
+
+	if q.options.sync == SyncAlways {
+		if err := q.log.Sync(); err != nil {
+			// The record was not applied in memory: it is discarded
+			// so as not to be recovered. As the content of the log
+			// which was not flushed is unknown, it is not written
+			// to anymore.
+			q.discard(offset)
+
+			return q.poison(fmt.Errorf("lane: syncing durable queue log: %w", err))
+		}
+	}
+
+	return nil
+}
+
+// discard truncates the log back to offset, and reports whether it succeeded.
+func (q *DurableQueue[T]) discard(offset int64) bool {
+	if err := q.log.Truncate(offset); err != nil {
+		return false
+	}
+
+	_, err := q.log.Seek(offset, io.SeekStart)
+
+	return err == nil
+}
+
+// poison closes the DurableQueue after a write left its log in an unknown
+// state, so that every later operation fails with err.
+func (q *DurableQueue[T]) poison(err error) error {
+	q.log.Close()
+	q.log = nil
+	q.failure = fmt.Errorf("%w: %w", ErrClosed, err)
+
+	return q.failure
+}
+
+// usable returns the error operations on the DurableQueue fail with,
+// if it was closed.
+func (q *DurableQueue[T]) usable() error {
+	if q.failure != nil {
+		return q.failure
+	}
+
+	if q.log == nil {
+		return ErrClosed
+	}
+
+	return nil
+}
+
+// compact writes the items left in the queue to a new log, which
+// then atomically replaces the current one.
+func (q *DurableQueue[T]) compact() error {
+	var records []byte
+
+	for i := uint(0); i < q.container.Len(); i++ {
+		payload, err := q.codec.Encode(q.container.at(i))
+		if err != nil {
+			return fmt.Errorf("lane: encoding item: %w", err)
+		}
+
+		records = appendRecord(records, opEnqueue, payload)
+	}
+
+	path := filepath.Join(q.dir, durableQueueCompactedLog)
+
+	compacted, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o640)
+	if err != nil {
+		return fmt.Errorf("lane: creating compacted log: %w", err)
+	}
+
+	if _, err := compacted.Write(records); err != nil {
+		compacted.Close()
+		return fmt.Errorf("lane: writing compacted log: %w", err)
+	}
+
+	if err := compacted.Sync(); err != nil {
+		compacted.Close()
+		return fmt.Errorf("lane: syncing compacted log: %w", err)
+	}
+
+	if err := os.Rename(path, filepath.Join(q.dir, durableQueueLog)); err != nil {
+		compacted.Close()
+		return fmt.Errorf("lane: replacing log with compacted log: %w", err)
+	}
+
+	q.log.Close()
+	q.log = compacted
+	q.dequeued = 0
+
+	return syncDir(q.dir)
+}
+
+// durableLog is the file holding a DurableQueue's log.
+type durableLog interface {
+	io.ReadWriteSeeker
+	io.Closer
+
+	Stat() (os.FileInfo, error)
+	Truncate(size int64) error
+	Sync() error
+}
+
+// syncDir flushes the directory entries of dir to stable storage.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("lane: opening durable queue directory: %w", err)
+	}
+	defer d.Close()
+
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("lane: syncing durable queue directory: %w", err)
+	}
+
+	return nil
+}
+
+// appendRecord appends the log record of op, holding payload, to buf.
+func appendRecord(buf []byte, op byte, payload []byte) []byte {
+	start := len(buf)
+
+	buf = append(buf, op)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(payload)))
+	buf = append(buf, payload...)
+
+	return binary.BigEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf[start:]))
+}
+
+// readRecord reads a log record from r, holding at most remaining bytes, and
+// returns its operation, its payload and its total size. It returns an error
+// if the record is incomplete, or if its checksum does not match its content.
+func readRecord(r io.Reader, remaining int64) (op byte, payload []byte, size int64, err error) {
+	header := make([]byte, recordHeaderSize)
+	if _, err = io.ReadFull(r, header); err != nil {
+		return
+	}
+
+	op = header[0]
+	if op != opEnqueue && op != opDequeue {
+		return 0, nil, 0, errors.New("lane: unknown log record operation")
+	}
+
+	bodySize := int64(binary.BigEndian.Uint32(header[1:])) + recordChecksumSize
+	if bodySize > remaining-recordHeaderSize {
+		return 0, nil, 0, io.ErrUnexpectedEOF
+	}
+
+	body := make([]byte, bodySize)
+	if _, err = io.ReadFull(r, body); err != nil {
+		return
+	}
+
+	payload = body[:len(body)-recordChecksumSize]
+	checksum := crc32.Update(crc32.ChecksumIEEE(header), crc32.IEEETable, payload)
+
+	if checksum != binary.BigEndian.Uint32(body[len(payload):]) {
+		return 0, nil, 0, errors.New("lane: log record checksum mismatch")
+	}
+
+	return op, payload, int64(len(header) + len(body)), nil
+}
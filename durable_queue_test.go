@@ -0,0 +1,396 @@
+package lane
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurableQueue(t *testing.T) {
+	t.Parallel()
+
+	queue, err := OpenDurableQueue[string](t.TempDir(), JSONCodec[string]{})
+	require.NoError(t, err)
+
+	defer queue.Close()
+
+	_, gotOk, gotErr := queue.Dequeue()
+	assert.NoError(t, gotErr)
+	assert.False(t, gotOk)
+	assert.True(t, queue.Empty())
+
+	require.NoError(t, queue.Enqueue("grumpyClient"))
+	require.NoError(t, queue.Enqueue("happyClient"))
+
+	gotHead, gotHeadOk := queue.Head()
+	assert.Equal(t, "grumpyClient", gotHead)
+	assert.True(t, gotHeadOk)
+	assert.Equal(t, uint(2), queue.Size())
+
+	gotItem, gotOk, gotErr := queue.Dequeue()
+	assert.NoError(t, gotErr)
+	assert.True(t, gotOk)
+	assert.Equal(t, "grumpyClient", gotItem)
+	assert.Equal(t, uint(1), queue.Size())
+}
+
+func TestDurableQueueRecovery(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		options   []DurableQueueOption
+		enqueue   []int
+		dequeue   int
+		corrupt   func(t *testing.T, path string)
+		wantItems []int
+	}{
+		{
+			desc:      "reopening an empty queue",
+			wantItems: []int{},
+		},
+		{
+			desc:      "reopening recovers enqueued and dequeued items",
+			enqueue:   []int{1, 2, 3, 4},
+			dequeue:   2,
+			wantItems: []int{3, 4},
+		},
+		{
+			desc:      "reopening without syncing writes recovers items",
+			options:   []DurableQueueOption{WithSyncPolicy(SyncNever)},
+			enqueue:   []int{1, 2, 3},
+			dequeue:   1,
+			wantItems: []int{2, 3},
+		},
+		{
+			desc:      "reopening after a compaction recovers items",
+			options:   []DurableQueueOption{WithCompactionThreshold(2)},
+			enqueue:   []int{1, 2, 3, 4, 5},
+			dequeue:   3,
+			wantItems: []int{4, 5},
+		},
+		{
+			desc:    "reopening discards a torn record",
+			enqueue: []int{1, 2, 3},
+			corrupt: func(t *testing.T, path string) {
+				info, err := os.Stat(path)
+				require.NoError(t, err)
+				require.NoError(t, os.Truncate(path, info.Size()-2))
+			},
+			wantItems: []int{1, 2},
+		},
+		{
+			desc:    "reopening discards a corrupted record",
+			enqueue: []int{1, 2, 3},
+			corrupt: func(t *testing.T, path string) {
+				data, err := os.ReadFile(path)
+				require.NoError(t, err)
+
+				data[len(data)-recordChecksumSize-1] ^= 0xff
+				require.NoError(t, os.WriteFile(path, data, 0o600))
+			},
+			wantItems: []int{1, 2},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			queue, err := OpenDurableQueue[int](dir, JSONCodec[int]{}, tC.options...)
+			require.NoError(t, err)
+
+			for _, item := range tC.enqueue {
+				require.NoError(t, queue.Enqueue(item))
+			}
+
+			for i := 0; i < tC.dequeue; i++ {
+				_, _, err = queue.Dequeue()
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, queue.Close())
+
+			if tC.corrupt != nil {
+				tC.corrupt(t, filepath.Join(dir, durableQueueLog))
+			}
+
+			reopened, err := OpenDurableQueue[int](dir, JSONCodec[int]{}, tC.options...)
+			require.NoError(t, err)
+
+			defer reopened.Close()
+
+			assert.Equal(t, tC.wantItems, durableQueueItems(reopened))
+		})
+	}
+}
+
+func TestDurableQueueWritesAfterRecoveringATornRecord(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, durableQueueLog)
+
+	queue, err := OpenDurableQueue[int](dir, JSONCodec[int]{})
+	require.NoError(t, err)
+	require.NoError(t, queue.Enqueue(1))
+	require.NoError(t, queue.Enqueue(2))
+	require.NoError(t, queue.Close())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-1))
+
+	queue, err = OpenDurableQueue[int](dir, JSONCodec[int]{})
+	require.NoError(t, err)
+	require.NoError(t, queue.Enqueue(3))
+	require.NoError(t, queue.Close())
+
+	queue, err = OpenDurableQueue[int](dir, JSONCodec[int]{})
+	require.NoError(t, err)
+
+	defer queue.Close()
+
+	assert.Equal(t, []int{1, 3}, durableQueueItems(queue))
+}
+
+func TestDurableQueueCompact(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, durableQueueLog)
+
+	queue, err := OpenDurableQueue[int](dir, JSONCodec[int]{})
+	require.NoError(t, err)
+
+	defer queue.Close()
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, queue.Enqueue(i))
+	}
+
+	for i := 0; i < 9; i++ {
+		_, _, err = queue.Dequeue()
+		require.NoError(t, err)
+	}
+
+	before, err := os.Stat(path)
+	require.NoError(t, err)
+
+	require.NoError(t, queue.Compact())
+
+	after, err := os.Stat(path)
+	require.NoError(t, err)
+
+	assert.Less(t, after.Size(), before.Size())
+
+	// Writes keep going to the compacted log.
+	require.NoError(t, queue.Enqueue(10))
+
+	item, ok, err := queue.Dequeue()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 9, item)
+}
+
+func TestDurableQueueDequeueFailedCompaction(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, durableQueueLog)
+	compactedPath := filepath.Join(dir, durableQueueCompactedLog)
+
+	queue, err := OpenDurableQueue[int](dir, JSONCodec[int]{}, WithCompactionThreshold(2))
+	require.NoError(t, err)
+
+	defer queue.Close()
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, queue.Enqueue(i))
+	}
+
+	// A directory standing in the way of the compacted log fails compactions.
+	require.NoError(t, os.Mkdir(compactedPath, 0o750))
+
+	for want := 0; want < 3; want++ {
+		item, ok, err := queue.Dequeue()
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, want, item)
+	}
+
+	before, err := os.Stat(path)
+	require.NoError(t, err)
+
+	// Compacting is retried by the next Dequeue.
+	require.NoError(t, os.Remove(compactedPath))
+	require.NoError(t, queue.Enqueue(4))
+
+	item, ok, err := queue.Dequeue()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, item)
+
+	after, err := os.Stat(path)
+	require.NoError(t, err)
+
+	assert.Less(t, after.Size(), before.Size())
+	assert.Equal(t, []int{4}, durableQueueItems(queue))
+}
+
+func TestDurableQueueClosed(t *testing.T) {
+	t.Parallel()
+
+	queue, err := OpenDurableQueue[int](t.TempDir(), JSONCodec[int]{})
+	require.NoError(t, err)
+	require.NoError(t, queue.Close())
+
+	_, _, gotDequeueErr := queue.Dequeue()
+
+	assert.ErrorIs(t, queue.Enqueue(1), ErrClosed)
+	assert.ErrorIs(t, gotDequeueErr, ErrClosed)
+	assert.ErrorIs(t, queue.Sync(), ErrClosed)
+	assert.ErrorIs(t, queue.Compact(), ErrClosed)
+	assert.ErrorIs(t, queue.Close(), ErrClosed)
+}
+
+func TestDurableQueueFailedWrite(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc       string
+		failWrite  bool
+		failSync   bool
+		wantClosed bool
+		wantItems  []int
+	}{
+		{
+			desc:       "a torn write is discarded, and the queue remains usable",
+			failWrite:  true,
+			wantClosed: false,
+			wantItems:  []int{1, 3},
+		},
+		{
+			desc:       "a failed sync is discarded, and closes the queue",
+			failSync:   true,
+			wantClosed: true,
+			wantItems:  []int{1},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			queue, err := OpenDurableQueue[int](dir, JSONCodec[int]{})
+			require.NoError(t, err)
+			require.NoError(t, queue.Enqueue(1))
+
+			queue.log = &failingDurableLog{
+				durableLog: queue.log,
+				failWrite:  tC.failWrite,
+				failSync:   tC.failSync,
+			}
+
+			gotErr := queue.Enqueue(2)
+			assert.ErrorIs(t, gotErr, errInjected)
+			assert.Equal(t, tC.wantClosed, errors.Is(gotErr, ErrClosed))
+
+			// The failed item was not enqueued, and is not
+			// recovered once later operations went through.
+			gotErr = queue.Enqueue(3)
+			if tC.wantClosed {
+				_, _, gotDequeueErr := queue.Dequeue()
+
+				assert.ErrorIs(t, gotErr, ErrClosed)
+				assert.ErrorIs(t, gotErr, errInjected)
+				assert.ErrorIs(t, gotDequeueErr, errInjected)
+				assert.ErrorIs(t, queue.Close(), errInjected)
+			} else {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, uint(2), queue.Size())
+				require.NoError(t, queue.Close())
+			}
+
+			reopened, err := OpenDurableQueue[int](dir, JSONCodec[int]{})
+			require.NoError(t, err)
+
+			defer reopened.Close()
+
+			assert.Equal(t, tC.wantItems, durableQueueItems(reopened))
+		})
+	}
+}
+
+func BenchmarkDurableQueueEnqueue(b *testing.B) {
+	b.ReportAllocs()
+
+	queue, err := OpenDurableQueue[int](b.TempDir(), JSONCodec[int]{}, WithSyncPolicy(SyncNever))
+	require.NoError(b, err)
+
+	defer queue.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = queue.Enqueue(i)
+	}
+}
+
+// durableQueueItems dequeues every item out of queue, and returns them in order.
+func durableQueueItems[T any](queue *DurableQueue[T]) []T {
+	items := []T{}
+
+	for {
+		item, ok, err := queue.Dequeue()
+		if err != nil || !ok {
+			return items
+		}
+
+		items = append(items, item)
+	}
+}
+
+// errInjected is the error returned by a failingDurableLog.
+var errInjected = errors.New("injected failure")
+
+// failingDurableLog is a durableLog failing its first write, writing half
+// of the record beforehand, or its first sync.
+type failingDurableLog struct {
+	durableLog
+
+	failWrite bool
+	failSync  bool
+}
+
+func (l *failingDurableLog) Write(p []byte) (int, error) {
+	if l.failWrite {
+		l.failWrite = false
+
+		n, _ := l.durableLog.Write(p[:len(p)/2])
+
+		return n, errInjected
+	}
+
+	return l.durableLog.Write(p)
+}
+
+func (l *failingDurableLog) Sync() error {
+	if l.failSync {
+		l.failSync = false
+		return errInjected
+	}
+
+	return l.durableLog.Sync()
+}
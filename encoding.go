@@ -0,0 +1,23 @@
+package lane
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// marshalGob returns the gob encoding of v.
+func marshalGob[T any](v T) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// unmarshalGob decodes the gob encoded data into a value of type T.
+func unmarshalGob[T any](data []byte) (v T, err error) {
+	err = gob.NewDecoder(bytes.NewReader(data)).Decode(&v)
+	return v, err
+}
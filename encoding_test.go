@@ -0,0 +1,305 @@
+package lane
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Every container implements the standard marshaling interfaces.
+var (
+	_ json.Marshaler             = (*Deque[int])(nil)
+	_ json.Unmarshaler           = (*Deque[int])(nil)
+	_ encoding.BinaryMarshaler   = (*Deque[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*Deque[int])(nil)
+	_ json.Unmarshaler           = (*BoundDeque[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*BoundDeque[int])(nil)
+	_ json.Marshaler             = (*RingDeque[int])(nil)
+	_ json.Unmarshaler           = (*RingDeque[int])(nil)
+	_ encoding.BinaryMarshaler   = (*RingDeque[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*RingDeque[int])(nil)
+	_ json.Marshaler             = (*Queue[int])(nil)
+	_ json.Unmarshaler           = (*Queue[int])(nil)
+	_ encoding.BinaryMarshaler   = (*Queue[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*Queue[int])(nil)
+	_ json.Marshaler             = (*Stack[int])(nil)
+	_ json.Unmarshaler           = (*Stack[int])(nil)
+	_ encoding.BinaryMarshaler   = (*Stack[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*Stack[int])(nil)
+	_ json.Marshaler             = (*PriorityQueue[int, int])(nil)
+	_ json.Unmarshaler           = (*PriorityQueue[int, int])(nil)
+	_ encoding.BinaryMarshaler   = (*PriorityQueue[int, int])(nil)
+	_ encoding.BinaryUnmarshaler = (*PriorityQueue[int, int])(nil)
+)
+
+func TestMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	pqueue := NewMinPriorityQueue[string, int]()
+	pqueue.Push("b", 2)
+	pqueue.Push("c", 3)
+	pqueue.Push("a", 1)
+
+	stablePQueue := NewMinPriorityQueue[string, int](WithStableOrdering())
+	stablePQueue.Push("a", 1)
+	stablePQueue.Push("b", 1)
+
+	testCases := []struct {
+		desc      string
+		container json.Marshaler
+		wantJSON  string
+	}{
+		{
+			desc:      "Deque encodes its items from front to back",
+			container: NewDeque(1, 2, 3),
+			wantJSON:  `[1,2,3]`,
+		},
+		{
+			desc:      "empty Deque encodes as an empty array",
+			container: NewDeque[int](),
+			wantJSON:  `[]`,
+		},
+		{
+			desc:      "RingDeque encodes its items from front to back",
+			container: NewRingDeque(1, 2, 3),
+			wantJSON:  `[1,2,3]`,
+		},
+		{
+			desc:      "Queue encodes its items in dequeuing order",
+			container: NewQueue(1, 2, 3),
+			wantJSON:  `[1,2,3]`,
+		},
+		{
+			desc:      "Stack encodes its items in popping order",
+			container: NewStack(1, 2, 3),
+			wantJSON:  `[1,2,3]`,
+		},
+		{
+			desc:      "PriorityQueue encodes its items laid out as in its heap",
+			container: pqueue,
+			wantJSON:  `[{"value":"a","priority":1},{"value":"c","priority":3},{"value":"b","priority":2}]`,
+		},
+		{
+			desc:      "stable PriorityQueue encodes the insertion rank of its items",
+			container: stablePQueue,
+			wantJSON:  `[{"value":"a","priority":1,"rank":1},{"value":"b","priority":1,"rank":2}]`,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			gotJSON, gotErr := json.Marshal(tC.container)
+
+			assert.NoError(t, gotErr)
+			assert.JSONEq(t, tC.wantJSON, string(gotJSON))
+		})
+	}
+}
+
+func TestMarshalingRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	type marshaler interface {
+		json.Marshaler
+		json.Unmarshaler
+		encoding.BinaryMarshaler
+		encoding.BinaryUnmarshaler
+	}
+
+	testCases := []struct {
+		desc  string
+		from  marshaler
+		into  func() marshaler
+		items func(marshaler) []int
+	}{
+		{
+			desc:  "Deque",
+			from:  NewDeque(1, 2, 3),
+			into:  func() marshaler { return NewDeque(42) },
+			items: func(m marshaler) []int { return slices.Collect(m.(*Deque[int]).All()) },
+		},
+		{
+			desc:  "zero value Deque",
+			from:  NewDeque(1, 2, 3),
+			into:  func() marshaler { return &Deque[int]{} },
+			items: func(m marshaler) []int { return slices.Collect(m.(*Deque[int]).All()) },
+		},
+		{
+			desc:  "BoundDeque",
+			from:  NewBoundDeque(3, 1, 2, 3),
+			into:  func() marshaler { return NewBoundDeque(3, 42) },
+			items: func(m marshaler) []int { return slices.Collect(m.(*BoundDeque[int]).All()) },
+		},
+		{
+			desc:  "RingDeque",
+			from:  NewRingDeque(1, 2, 3),
+			into:  func() marshaler { return NewRingDeque(42) },
+			items: func(m marshaler) []int { return slices.Collect(m.(*RingDeque[int]).All()) },
+		},
+		{
+			desc:  "Queue",
+			from:  NewQueue(1, 2, 3),
+			into:  func() marshaler { return NewQueue(42) },
+			items: func(m marshaler) []int { return slices.Collect(m.(*Queue[int]).All()) },
+		},
+		{
+			desc:  "Stack",
+			from:  NewStack(1, 2, 3),
+			into:  func() marshaler { return NewStack(42) },
+			items: func(m marshaler) []int { return slices.Collect(m.(*Stack[int]).All()) },
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			want := tC.items(tC.from)
+
+			jsonData, err := tC.from.MarshalJSON()
+			require.NoError(t, err)
+
+			fromJSON := tC.into()
+			require.NoError(t, fromJSON.UnmarshalJSON(jsonData))
+			assert.Equal(t, want, tC.items(fromJSON))
+
+			binaryData, err := tC.from.MarshalBinary()
+			require.NoError(t, err)
+
+			fromBinary := tC.into()
+			require.NoError(t, fromBinary.UnmarshalBinary(binaryData))
+			assert.Equal(t, want, tC.items(fromBinary))
+		})
+	}
+}
+
+func TestPriorityQueueMarshalingRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	from := NewMaxPriorityQueue[string, int](WithStableOrdering())
+	from.Push("b1", 2)
+	from.Push("a", 1)
+	from.Push("c", 3)
+	from.Push("b2", 2)
+	from.Push("b3", 2)
+
+	jsonData, err := from.MarshalJSON()
+	require.NoError(t, err)
+
+	fromJSON := NewMaxPriorityQueue[string, int](WithStableOrdering())
+	require.NoError(t, fromJSON.UnmarshalJSON(jsonData))
+
+	binaryData, err := from.MarshalBinary()
+	require.NoError(t, err)
+
+	fromBinary := NewMaxPriorityQueue[string, int](WithStableOrdering())
+	require.NoError(t, fromBinary.UnmarshalBinary(binaryData))
+
+	wantPopOrder := []string{"c", "b1", "b2", "b3", "a"}
+	assert.Equal(t, wantPopOrder, popValues(fromJSON))
+	assert.Equal(t, wantPopOrder, popValues(fromBinary))
+}
+
+func TestPriorityQueueMarshalingRoundTripEqualPriorities(t *testing.T) {
+	t.Parallel()
+
+	for seed := uint64(0); seed < 50; seed++ {
+		from := equalPrioritiesPriorityQueue(seed)
+
+		jsonData, err := json.Marshal(from)
+		require.NoError(t, err)
+
+		fromJSON := NewMaxPriorityQueue[int, int]()
+		require.NoError(t, json.Unmarshal(jsonData, fromJSON))
+
+		binaryData, err := from.MarshalBinary()
+		require.NoError(t, err)
+
+		fromBinary := NewMaxPriorityQueue[int, int]()
+		require.NoError(t, fromBinary.UnmarshalBinary(binaryData))
+
+		// Items of equal priorities are popped in the same
+		// order, even on unstable queues.
+		gotJSONValues, _ := fromJSON.PopN(fromJSON.Size())
+		gotBinaryValues, _ := fromBinary.PopN(fromBinary.Size())
+		wantValues, _ := from.PopN(from.Size())
+
+		assert.Equal(t, wantValues, gotJSONValues)
+		assert.Equal(t, wantValues, gotBinaryValues)
+	}
+}
+
+func TestPriorityQueueUnmarshalJSONRestoresHeapOrdering(t *testing.T) {
+	t.Parallel()
+
+	pqueue := NewMinPriorityQueue[string, int]()
+	data := `[{"value":"c","priority":3},{"value":"a","priority":1},{"value":"b","priority":2}]`
+
+	require.NoError(t, json.Unmarshal([]byte(data), pqueue))
+
+	assert.Equal(t, []string{"a", "b", "c"}, popValues(pqueue))
+}
+
+func TestPriorityQueueUnmarshalWithoutHeuristic(t *testing.T) {
+	t.Parallel()
+
+	var pqueue PriorityQueue[string, int]
+
+	gotErr := json.Unmarshal([]byte(`[{"value":"a","priority":1}]`), &pqueue)
+
+	assert.ErrorIs(t, gotErr, ErrNoHeuristic)
+}
+
+func TestBoundDequeUnmarshalExceedingCapacity(t *testing.T) {
+	t.Parallel()
+
+	from := NewDeque(1, 2, 3)
+	deque := NewBoundDeque(2, 42)
+
+	jsonData, err := from.MarshalJSON()
+	require.NoError(t, err)
+
+	binaryData, err := from.MarshalBinary()
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, deque.UnmarshalJSON(jsonData), ErrCapacityExceeded)
+	assert.ErrorIs(t, deque.UnmarshalBinary(binaryData), ErrCapacityExceeded)
+	assert.Equal(t, []int{42}, slices.Collect(deque.All()))
+}
+
+func TestBoundDequeUnmarshalWakesProducers(t *testing.T) {
+	t.Parallel()
+
+	deque := NewBoundDeque(2, 1, 2)
+
+	inserted := make(chan error)
+	go func() {
+		inserted <- deque.AppendWait(context.Background(), 3)
+	}()
+
+	assert.Eventually(t, func() bool { return producersWaiting(deque) == 1 }, time.Second, time.Millisecond)
+
+	// Unmarshaling fewer items frees up capacity for the blocked producer.
+	require.NoError(t, json.Unmarshal([]byte(`[9]`), deque))
+
+	select {
+	case err := <-inserted:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("producer was not woken up once capacity was freed")
+	}
+
+	assert.Equal(t, []int{9, 3}, slices.Collect(deque.All()))
+}
@@ -0,0 +1,25 @@
+package lane
+
+import "iter"
+
+// forward returns an iterator over items, from the first to the last one.
+func forward[T any](items []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// backward returns an iterator over items, from the last to the first one.
+func backward[T any](items []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(items) - 1; i >= 0; i-- {
+			if !yield(items[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package lane
+
+import "sync/atomic"
+
+// LockFreeQueue is a First In First Out data structure implementation,
+// safe for concurrent use by multiple producers and multiple consumers
+// without relying on locks.
+//
+// It implements the Michael-Scott non-blocking queue algorithm: a singly
+// linked list whose head and tail are updated using atomic compare-and-swap
+// operations. Goroutines never block each other, which keeps its throughput
+// steady under heavy contention, where the lock of a Queue would serialize
+// every operation.
+//
+// Its API mirrors the Queue's core functionalities: Enqueue, Dequeue, Head,
+// Size, Empty. Every operation has an amortized *O(1)* time complexity,
+// retrying as long as it contends with operations of other goroutines.
+//
+// A LockFreeQueue must be created using NewLockFreeQueue.
+type LockFreeQueue[T any] struct {
+	// head points to a sentinel node, whose successor holds
+	// the front item of the queue, if any.
+	head atomic.Pointer[lockFreeNode[T]]
+
+	// tail points to the last, or next to last, node of the queue.
+	tail atomic.Pointer[lockFreeNode[T]]
+
+	size atomic.Int64
+}
+
+// lockFreeNode is a node of a LockFreeQueue's linked list.
+type lockFreeNode[T any] struct {
+	value T
+	next  atomic.Pointer[lockFreeNode[T]]
+}
+
+// NewLockFreeQueue produces a new LockFreeQueue instance.
+func NewLockFreeQueue[T any](items ...T) *LockFreeQueue[T] {
+	queue := &LockFreeQueue[T]{}
+
+	sentinel := &lockFreeNode[T]{}
+	queue.head.Store(sentinel)
+	queue.tail.Store(sentinel)
+
+	for _, item := range items {
+		queue.Enqueue(item)
+	}
+
+	return queue
+}
+
+// Enqueue adds an item at the back of the LockFreeQueue.
+func (q *LockFreeQueue[T]) Enqueue(item T) {
+	node := &lockFreeNode[T]{value: item}
+
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+
+		if tail != q.tail.Load() {
+			continue
+		}
+
+		// The tail is lagging behind, because another goroutine
+		// is in the middle of an Enqueue: help it move forward.
+		if next != nil {
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		if tail.next.CompareAndSwap(nil, node) {
+			q.tail.CompareAndSwap(tail, node)
+			q.size.Add(1)
+
+			return
+		}
+	}
+}
+
+// Dequeue removes and returns the LockFreeQueue's front item.
+func (q *LockFreeQueue[T]) Dequeue() (item T, ok bool) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+
+		if head != q.head.Load() {
+			continue
+		}
+
+		if next == nil {
+			return item, false
+		}
+
+		// The tail is lagging behind, because another goroutine
+		// is in the middle of an Enqueue: help it move forward.
+		if head == tail {
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		// The front node becomes the new sentinel. Its value is not
+		// cleared, as concurrent calls to Head might still read it: it is
+		// released once the node is dequeued in turn.
+		if q.head.CompareAndSwap(head, next) {
+			q.size.Add(-1)
+			return next.value, true
+		}
+	}
+}
+
+// Head returns the LockFreeQueue's front item.
+func (q *LockFreeQueue[T]) Head() (item T, ok bool) {
+	next := q.head.Load().next.Load()
+	if next == nil {
+		return item, false
+	}
+
+	return next.value, true
+}
+
+// Size returns the size of the LockFreeQueue.
+//
+// While operations are in progress in other goroutines, the returned
+// size is only an approximation.
+func (q *LockFreeQueue[T]) Size() uint {
+	// The counter is updated after the items are linked or unlinked,
+	// so it can transiently drop below zero.
+	if size := q.size.Load(); size > 0 {
+		return uint(size)
+	}
+
+	return 0
+}
+
+// Empty returns whether the LockFreeQueue is empty.
+func (q *LockFreeQueue[T]) Empty() bool {
+	return q.head.Load().next.Load() == nil
+}
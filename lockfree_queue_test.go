@@ -0,0 +1,228 @@
+package lane
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockFreeQueue(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc          string
+		queue         *LockFreeQueue[int]
+		enqueue       []int
+		wantHead      int
+		wantHeadOk    bool
+		wantSize      uint
+		wantDequeued  []int
+		wantDequeueOk bool
+	}{
+		{
+			desc:  "operations on an empty LockFreeQueue",
+			queue: NewLockFreeQueue[int](),
+		},
+		{
+			desc:          "NewLockFreeQueue with initializer produces FIFO ordering",
+			queue:         NewLockFreeQueue([]int{1, 2, 3}...),
+			wantHead:      1,
+			wantHeadOk:    true,
+			wantSize:      3,
+			wantDequeued:  []int{1, 2, 3},
+			wantDequeueOk: true,
+		},
+		{
+			desc:          "Enqueue inserts values at the back",
+			queue:         NewLockFreeQueue([]int{1}...),
+			enqueue:       []int{2, 3},
+			wantHead:      1,
+			wantHeadOk:    true,
+			wantSize:      3,
+			wantDequeued:  []int{1, 2, 3},
+			wantDequeueOk: true,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			for _, item := range tC.enqueue {
+				tC.queue.Enqueue(item)
+			}
+
+			gotHead, gotHeadOk := tC.queue.Head()
+
+			assert.Equal(t, tC.wantHead, gotHead)
+			assert.Equal(t, tC.wantHeadOk, gotHeadOk)
+			assert.Equal(t, tC.wantSize, tC.queue.Size())
+			assert.Equal(t, tC.wantSize == 0, tC.queue.Empty())
+
+			var gotDequeued []int
+			for {
+				item, ok := tC.queue.Dequeue()
+				if !ok {
+					break
+				}
+
+				gotDequeued = append(gotDequeued, item)
+			}
+
+			assert.Equal(t, tC.wantDequeued, gotDequeued)
+			assert.True(t, tC.queue.Empty())
+		})
+	}
+}
+
+func TestLockFreeQueueConcurrentProducersAndConsumers(t *testing.T) {
+	t.Parallel()
+
+	const (
+		producers = 8
+		consumers = 8
+		items     = 1000
+	)
+
+	queue := NewLockFreeQueue[int]()
+
+	var producersWg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		producersWg.Add(1)
+
+		go func(p int) {
+			defer producersWg.Done()
+
+			for i := 0; i < items; i++ {
+				queue.Enqueue(p*items + i)
+			}
+		}(p)
+	}
+
+	var (
+		consumersWg sync.WaitGroup
+		remaining   atomic.Int64
+	)
+
+	remaining.Store(producers * items)
+	dequeued := make([][]int, consumers)
+
+	for c := 0; c < consumers; c++ {
+		consumersWg.Add(1)
+
+		go func(c int) {
+			defer consumersWg.Done()
+
+			for remaining.Load() > 0 {
+				item, ok := queue.Dequeue()
+				if !ok {
+					runtime.Gosched()
+					continue
+				}
+
+				remaining.Add(-1)
+				dequeued[c] = append(dequeued[c], item)
+			}
+		}(c)
+	}
+
+	producersWg.Wait()
+	consumersWg.Wait()
+
+	var all []int
+	for _, consumed := range dequeued {
+		// Each consumer sees the items of a given producer
+		// in the order they were enqueued.
+		last := make(map[int]int)
+		for _, item := range consumed {
+			p, i := item/items, item%items
+			if previous, ok := last[p]; ok {
+				assert.Greater(t, i, previous)
+			}
+			last[p] = i
+		}
+
+		all = append(all, consumed...)
+	}
+
+	want := make([]int, producers*items)
+	for i := range want {
+		want[i] = i
+	}
+
+	assert.ElementsMatch(t, want, all)
+	assert.True(t, queue.Empty())
+	assert.Equal(t, uint(0), queue.Size())
+}
+
+func BenchmarkLockFreeQueueEnqueue(b *testing.B) {
+	b.ReportAllocs()
+
+	queue := NewLockFreeQueue[int]()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		queue.Enqueue(i)
+	}
+}
+
+func BenchmarkLockFreeQueueDequeue(b *testing.B) {
+	b.ReportAllocs()
+
+	queue := NewLockFreeQueue[int]()
+
+	for i := 0; i < b.N; i++ {
+		queue.Enqueue(i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		queue.Dequeue()
+	}
+}
+
+// queueBenchmarker is the set of operations shared by Queue and LockFreeQueue
+// that BenchmarkQueueContention exercises.
+type queueBenchmarker interface {
+	Enqueue(item int)
+	Dequeue() (int, bool)
+}
+
+// BenchmarkQueueContention compares the Queue with the LockFreeQueue, with
+// an increasing number of goroutines concurrently enqueuing and dequeuing.
+func BenchmarkQueueContention(b *testing.B) {
+	implementations := []struct {
+		name  string
+		queue func() queueBenchmarker
+	}{
+		{name: "mutex", queue: func() queueBenchmarker { return NewQueue[int]() }},
+		{name: "lockfree", queue: func() queueBenchmarker { return NewLockFreeQueue[int]() }},
+	}
+
+	for _, impl := range implementations {
+		for _, parallelism := range []int{1, 4, 16, 64} {
+			impl, parallelism := impl, parallelism
+
+			b.Run(fmt.Sprintf("%s/goroutines=%dxGOMAXPROCS", impl.name, parallelism), func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetParallelism(parallelism)
+
+				queue := impl.queue()
+
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					for i := 0; pb.Next(); i++ {
+						queue.Enqueue(i)
+						queue.Dequeue()
+					}
+				})
+			})
+		}
+	}
+}
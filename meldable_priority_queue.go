@@ -0,0 +1,202 @@
+package lane
+
+import (
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+// MeldablePriorityQueue is a priority queue supporting the efficient
+// merging of two instances.
+//
+// It is built upon a pairing heap: pushing an item, or merging another
+// MeldablePriorityQueue into it happens in *O(1)* time complexity, while
+// popping an item happens in amortized *O(log n)*.
+//
+// It can either be min (ascending) or max (descending) oriented/ordered,
+// using the same comparison heuristics as PriorityQueue.
+//
+// Every operation on a MeldablePriorityQueue is goroutine-safe.
+type MeldablePriorityQueue[T any, P any] struct {
+	sync.RWMutex
+
+	// root is the pairing heap's root, holding its head item.
+	root *pairingHeapNode[T, P]
+
+	size       uint
+	comparator func(lhs, rhs P) bool
+}
+
+// NewMeldablePriorityQueue produces a new MeldablePriorityQueue instance,
+// ordering its items using the provided heuristic, which reports whether
+// `rhs` should be served before `lhs`.
+func NewMeldablePriorityQueue[T any, P any](heuristic func(lhs, rhs P) bool) *MeldablePriorityQueue[T, P] {
+	return &MeldablePriorityQueue[T, P]{
+		comparator: heuristic,
+	}
+}
+
+// NewMaxMeldablePriorityQueue instantiates a new maximum oriented MeldablePriorityQueue.
+func NewMaxMeldablePriorityQueue[T any, P constraints.Ordered]() *MeldablePriorityQueue[T, P] {
+	return NewMeldablePriorityQueue[T](Maximum[P])
+}
+
+// NewMinMeldablePriorityQueue instantiates a new minimum oriented MeldablePriorityQueue.
+func NewMinMeldablePriorityQueue[T any, P constraints.Ordered]() *MeldablePriorityQueue[T, P] {
+	return NewMeldablePriorityQueue[T](Minimum[P])
+}
+
+// Push inserts the value in the MeldablePriorityQueue with the provided
+// priority in *O(1)* time complexity.
+func (pq *MeldablePriorityQueue[T, P]) Push(value T, priority P) {
+	node := &pairingHeapNode[T, P]{value: value, priority: priority}
+
+	pq.Lock()
+	defer pq.Unlock()
+
+	pq.root = pq.meld(pq.root, node)
+	pq.size++
+}
+
+// Pop removes and returns the highest or lowest priority item (depending on
+// the comparison heuristic of your MeldablePriorityQueue) from the
+// MeldablePriorityQueue in amortized *O(log n)* time complexity.
+func (pq *MeldablePriorityQueue[T, P]) Pop() (value T, priority P, ok bool) {
+	pq.Lock()
+	defer pq.Unlock()
+
+	if pq.root == nil {
+		return value, priority, false
+	}
+
+	root := pq.root
+	pq.root = pq.mergePairs(root.child)
+	pq.size--
+
+	return root.value, root.priority, true
+}
+
+// Head returns the highest or lowest priority item (depending on the
+// comparison heuristic of your MeldablePriorityQueue) from the
+// MeldablePriorityQueue in *O(1)* time complexity.
+func (pq *MeldablePriorityQueue[T, P]) Head() (value T, priority P, ok bool) {
+	pq.RLock()
+	defer pq.RUnlock()
+
+	if pq.root == nil {
+		return value, priority, false
+	}
+
+	return pq.root.value, pq.root.priority, true
+}
+
+// Size returns the number of elements present in the MeldablePriorityQueue.
+func (pq *MeldablePriorityQueue[T, P]) Size() uint {
+	pq.RLock()
+	defer pq.RUnlock()
+
+	return pq.size
+}
+
+// Empty returns whether the MeldablePriorityQueue is empty.
+func (pq *MeldablePriorityQueue[T, P]) Empty() bool {
+	pq.RLock()
+	defer pq.RUnlock()
+
+	return pq.size == 0
+}
+
+// Merge moves all the items of other into the MeldablePriorityQueue in *O(1)*
+// time complexity, leaving other empty. Both instances are expected to share
+// the same comparison heuristic: the merged items are ordered according to
+// the MeldablePriorityQueue's.
+//
+// Merging a MeldablePriorityQueue into itself has no effect.
+func (pq *MeldablePriorityQueue[T, P]) Merge(other *MeldablePriorityQueue[T, P]) {
+	if other == nil || other == pq {
+		return
+	}
+
+	// The content of other is detached before acquiring the
+	// MeldablePriorityQueue's lock, so that both locks are never held
+	// at once, and concurrent merges in opposite directions cannot
+	// deadlock.
+	other.Lock()
+	root, size := other.root, other.size
+	other.root, other.size = nil, 0
+	other.Unlock()
+
+	pq.Lock()
+	defer pq.Unlock()
+
+	pq.root = pq.meld(pq.root, root)
+	pq.size += size
+}
+
+// meld merges the pairing heaps rooted at lhs and rhs, and returns the
+// resulting root: the root served first becomes the other one's parent.
+func (pq *MeldablePriorityQueue[T, P]) meld(lhs, rhs *pairingHeapNode[T, P]) *pairingHeapNode[T, P] {
+	switch {
+	case lhs == nil:
+		return rhs
+	case rhs == nil:
+		return lhs
+	}
+
+	if pq.comparator(lhs.priority, rhs.priority) {
+		lhs, rhs = rhs, lhs
+	}
+
+	rhs.sibling = lhs.child
+	lhs.child = rhs
+
+	return lhs
+}
+
+// mergePairs melds the sibling list starting at first into a single
+// pairing heap, using the two-pass strategy: siblings are first melded by
+// pairs from left to right, and the resulting heaps are then melded from
+// right to left.
+func (pq *MeldablePriorityQueue[T, P]) mergePairs(first *pairingHeapNode[T, P]) *pairingHeapNode[T, P] {
+	// The first pass stacks up the melded pairs, linked through
+	// their sibling pointers, so that the second pass unstacks
+	// them from right to left.
+	var pairs *pairingHeapNode[T, P]
+	for first != nil {
+		lhs, rhs := first, first.sibling
+		first = nil
+
+		if rhs != nil {
+			first = rhs.sibling
+			rhs.sibling = nil
+		}
+
+		lhs.sibling = nil
+
+		pair := pq.meld(lhs, rhs)
+		pair.sibling = pairs
+		pairs = pair
+	}
+
+	var root *pairingHeapNode[T, P]
+	for pairs != nil {
+		pair := pairs
+		pairs = pair.sibling
+		pair.sibling = nil
+
+		root = pq.meld(root, pair)
+	}
+
+	return root
+}
+
+// pairingHeapNode is the underlying MeldablePriorityQueue item container.
+type pairingHeapNode[T any, P any] struct {
+	value    T
+	priority P
+
+	// child is the node's leftmost child, and sibling the
+	// next child of the node's parent.
+	child   *pairingHeapNode[T, P]
+	sibling *pairingHeapNode[T, P]
+}
@@ -0,0 +1,270 @@
+package lane
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeldablePriorityQueuePop(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc       string
+		pqueue     *MeldablePriorityQueue[int, int]
+		priorities []int
+		want       []int
+	}{
+		{
+			desc:   "Pop on an empty MeldablePriorityQueue",
+			pqueue: NewMaxMeldablePriorityQueue[int, int](),
+			want:   []int{},
+		},
+		{
+			desc:       "Pop on a max MeldablePriorityQueue",
+			pqueue:     NewMaxMeldablePriorityQueue[int, int](),
+			priorities: []int{41, 43, 40, 44, 42},
+			want:       []int{44, 43, 42, 41, 40},
+		},
+		{
+			desc:       "Pop on a min MeldablePriorityQueue",
+			pqueue:     NewMinMeldablePriorityQueue[int, int](),
+			priorities: []int{41, 43, 40, 44, 42},
+			want:       []int{40, 41, 42, 43, 44},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			for _, priority := range tC.priorities {
+				tC.pqueue.Push(priority, priority)
+			}
+
+			assert.Equal(t, uint(len(tC.want)), tC.pqueue.Size())
+			assert.Equal(t, tC.want, meldablePopValues(tC.pqueue))
+
+			_, _, gotOk := tC.pqueue.Pop()
+			assert.False(t, gotOk)
+			assert.True(t, tC.pqueue.Empty())
+		})
+	}
+}
+
+func TestMeldablePriorityQueueHead(t *testing.T) {
+	t.Parallel()
+
+	pqueue := NewMinMeldablePriorityQueue[string, int]()
+
+	_, _, gotOk := pqueue.Head()
+	assert.False(t, gotOk)
+
+	pqueue.Push("b", 2)
+	pqueue.Push("a", 1)
+
+	gotValue, gotPriority, gotOk := pqueue.Head()
+	assert.Equal(t, "a", gotValue)
+	assert.Equal(t, 1, gotPriority)
+	assert.True(t, gotOk)
+	assert.Equal(t, uint(2), pqueue.Size())
+}
+
+func TestMeldablePriorityQueueMerge(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		lhs       []int
+		rhs       []int
+		wantItems []int
+	}{
+		{
+			desc:      "Merge two empty MeldablePriorityQueues",
+			wantItems: []int{},
+		},
+		{
+			desc:      "Merge an empty MeldablePriorityQueue",
+			lhs:       []int{41, 40},
+			wantItems: []int{41, 40},
+		},
+		{
+			desc:      "Merge into an empty MeldablePriorityQueue",
+			rhs:       []int{41, 40},
+			wantItems: []int{41, 40},
+		},
+		{
+			desc:      "Merge two filled MeldablePriorityQueues",
+			lhs:       []int{40, 43, 44},
+			rhs:       []int{42, 41, 45},
+			wantItems: []int{45, 44, 43, 42, 41, 40},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			lhs := NewMaxMeldablePriorityQueue[int, int]()
+			for _, priority := range tC.lhs {
+				lhs.Push(priority, priority)
+			}
+
+			rhs := NewMaxMeldablePriorityQueue[int, int]()
+			for _, priority := range tC.rhs {
+				rhs.Push(priority, priority)
+			}
+
+			lhs.Merge(rhs)
+
+			assert.True(t, rhs.Empty())
+			assert.Equal(t, uint(len(tC.wantItems)), lhs.Size())
+			assert.Equal(t, tC.wantItems, meldablePopValues(lhs))
+		})
+	}
+}
+
+func TestMeldablePriorityQueueMergeItself(t *testing.T) {
+	t.Parallel()
+
+	pqueue := NewMaxMeldablePriorityQueue[int, int]()
+	pqueue.Push(42, 42)
+
+	pqueue.Merge(pqueue)
+	pqueue.Merge(nil)
+
+	assert.Equal(t, []int{42}, meldablePopValues(pqueue))
+}
+
+func TestMeldablePriorityQueueConcurrentMerge(t *testing.T) {
+	t.Parallel()
+
+	const items = 1000
+
+	lhs := NewMinMeldablePriorityQueue[int, int]()
+	rhs := NewMinMeldablePriorityQueue[int, int]()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// Merging in opposite directions concurrently must not deadlock,
+	// nor lose any item.
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < items; i++ {
+			lhs.Push(i, i)
+			lhs.Merge(rhs)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for i := items; i < 2*items; i++ {
+			rhs.Push(i, i)
+			rhs.Merge(lhs)
+		}
+	}()
+
+	wg.Wait()
+	lhs.Merge(rhs)
+
+	got := meldablePopValues(lhs)
+	assert.Len(t, got, 2*items)
+	assert.True(t, slices.IsSorted(got))
+}
+
+func TestMeldablePriorityQueueOrdering(t *testing.T) {
+	t.Parallel()
+
+	random := rand.New(rand.NewPCG(1, 2))
+	pqueue := NewMinMeldablePriorityQueue[int, int]()
+
+	var want []int
+	for i := 0; i < 1000; i++ {
+		other := NewMinMeldablePriorityQueue[int, int]()
+
+		for j := random.IntN(10); j > 0; j-- {
+			priority := random.IntN(100)
+			other.Push(priority, priority)
+			want = append(want, priority)
+		}
+
+		pqueue.Merge(other)
+
+		for j := random.IntN(5); j > 0 && len(want) > 0; j-- {
+			slices.Sort(want)
+
+			_, gotPriority, _ := pqueue.Pop()
+			assert.Equal(t, want[0], gotPriority)
+			want = want[1:]
+		}
+	}
+
+	slices.Sort(want)
+	assert.Equal(t, want, meldablePopValues(pqueue))
+}
+
+func BenchmarkMeldablePriorityQueuePush(b *testing.B) {
+	b.ReportAllocs()
+
+	pqueue := NewMaxMeldablePriorityQueue[int, int]()
+
+	for i := 0; i < b.N; i++ {
+		pqueue.Push(i, i)
+	}
+}
+
+func BenchmarkMeldablePriorityQueuePop(b *testing.B) {
+	b.ReportAllocs()
+
+	pqueue := NewMaxMeldablePriorityQueue[int, int]()
+
+	for i := 0; i < b.N; i++ {
+		pqueue.Push(i, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pqueue.Pop()
+	}
+}
+
+func BenchmarkMeldablePriorityQueueMerge(b *testing.B) {
+	b.ReportAllocs()
+
+	pqueue := NewMaxMeldablePriorityQueue[int, int]()
+	others := make([]*MeldablePriorityQueue[int, int], b.N)
+
+	for i := range others {
+		others[i] = NewMaxMeldablePriorityQueue[int, int]()
+		others[i].Push(i, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pqueue.Merge(others[i])
+	}
+}
+
+// meldablePopValues pops all the values of pqueue, in order.
+func meldablePopValues[T any, P any](pqueue *MeldablePriorityQueue[T, P]) []T {
+	values := []T{}
+
+	for {
+		value, _, ok := pqueue.Pop()
+		if !ok {
+			return values
+		}
+
+		values = append(values, value)
+	}
+}
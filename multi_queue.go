@@ -0,0 +1,157 @@
+package lane
+
+import (
+	"math/rand/v2"
+	"runtime"
+	"sync/atomic"
+
+	"golang.org/x/exp/constraints"
+)
+
+// MultiQueue is a relaxed priority queue, designed to scale with the number
+// of goroutines concurrently pushing and popping items.
+//
+// A PriorityQueue serializes every operation behind a single lock, which
+// becomes a bottleneck under heavy contention. A MultiQueue instead spreads
+// its items over m independent PriorityQueue shards: items are pushed into
+// a random shard, and popped from the better of the heads of two random
+// shards. Goroutines thus rarely contend over the same lock.
+//
+// In exchange, ordering is relaxed: Pop does not always return the highest
+// or lowest priority item, but one close to it. Choosing the better of two
+// random shards keeps the expected rank error, that is the number of items
+// which should have been served before the popped one, in *O(m)*, and in
+// *O(m log m)* with high probability, regardless of the number of items.
+// Each operation has the time complexity of the underlying PriorityQueue
+// operation, at most *O(log n)*.
+//
+// Every operation on a MultiQueue is goroutine-safe.
+type MultiQueue[T any, P any] struct {
+	shards     []*PriorityQueue[T, P]
+	comparator func(lhs, rhs P) bool
+
+	size atomic.Int64
+}
+
+// NewMultiQueue instantiates a new MultiQueue, with the provided number of
+// shards, ordering items using the provided comparison heuristic, which
+// reports whether `rhs` should be served before `lhs`.
+//
+// The more shards, the less contention, but the larger the rank error: a
+// small multiple of the number of goroutines operating on the MultiQueue
+// strikes a good balance. A zero number of shards defaults to twice
+// runtime.GOMAXPROCS.
+func NewMultiQueue[T any, P any](shards uint, heuristic func(lhs, rhs P) bool) *MultiQueue[T, P] {
+	if shards == 0 {
+		shards = 2 * uint(runtime.GOMAXPROCS(0))
+	}
+
+	q := &MultiQueue[T, P]{
+		shards:     make([]*PriorityQueue[T, P], shards),
+		comparator: heuristic,
+	}
+
+	for i := range q.shards {
+		q.shards[i] = NewPriorityQueue[T](heuristic)
+	}
+
+	return q
+}
+
+// NewMaxMultiQueue instantiates a new maximum oriented MultiQueue.
+func NewMaxMultiQueue[T any, P constraints.Ordered](shards uint) *MultiQueue[T, P] {
+	return NewMultiQueue[T](shards, Maximum[P])
+}
+
+// NewMinMultiQueue instantiates a new minimum oriented MultiQueue.
+func NewMinMultiQueue[T any, P constraints.Ordered](shards uint) *MultiQueue[T, P] {
+	return NewMultiQueue[T](shards, Minimum[P])
+}
+
+// Push inserts the value in a random shard of the MultiQueue with the
+// provided priority, in at most *O(log n)* time complexity.
+func (q *MultiQueue[T, P]) Push(value T, priority P) {
+	q.shards[rand.IntN(len(q.shards))].Push(value, priority)
+	q.size.Add(1)
+}
+
+// Pop removes and returns a high or low priority item (depending on the
+// comparison heuristic of your MultiQueue) from the MultiQueue, in at most
+// *O(log n)* time complexity: the head of the better of two random shards.
+//
+// Pop only returns false if it found every shard empty.
+func (q *MultiQueue[T, P]) Pop() (value T, priority P, ok bool) {
+	if len(q.shards) > 1 {
+		shard := q.choose()
+
+		if value, priority, ok = shard.Pop(); ok {
+			q.size.Add(-1)
+			return value, priority, true
+		}
+	}
+
+	// Both chosen shards were empty, or got emptied concurrently: fall
+	// back to scanning every shard, starting from a random one.
+	offset := rand.IntN(len(q.shards))
+	for i := range q.shards {
+		shard := q.shards[(offset+i)%len(q.shards)]
+
+		if value, priority, ok = shard.Pop(); ok {
+			q.size.Add(-1)
+			return value, priority, true
+		}
+	}
+
+	return value, priority, false
+}
+
+// Size returns the number of elements present in the MultiQueue.
+//
+// Under concurrent operations, it is an approximation.
+func (q *MultiQueue[T, P]) Size() uint {
+	// The counter is updated after the items are pushed or popped,
+	// so it can transiently drop below zero.
+	if size := q.size.Load(); size > 0 {
+		return uint(size)
+	}
+
+	return 0
+}
+
+// Empty returns whether the MultiQueue is empty.
+//
+// Under concurrent operations, it is an approximation.
+func (q *MultiQueue[T, P]) Empty() bool {
+	return q.Size() == 0
+}
+
+// Shards returns the number of shards of the MultiQueue.
+func (q *MultiQueue[T, P]) Shards() uint {
+	return uint(len(q.shards))
+}
+
+// choose picks two distinct random shards, and returns the one whose head
+// should be served first. Heads are compared without locking both shards at
+// once, so the returned shard's head may have changed concurrently.
+func (q *MultiQueue[T, P]) choose() *PriorityQueue[T, P] {
+	i := rand.IntN(len(q.shards))
+
+	j := rand.IntN(len(q.shards) - 1)
+	if j >= i {
+		j++
+	}
+
+	lhs, rhs := q.shards[i], q.shards[j]
+
+	_, lhsPriority, lhsOk := lhs.Head()
+	_, rhsPriority, rhsOk := rhs.Head()
+
+	switch {
+	case !lhsOk:
+		return rhs
+	case rhsOk && q.comparator(lhsPriority, rhsPriority):
+		return rhs
+	default:
+		return lhs
+	}
+}
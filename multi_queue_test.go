@@ -0,0 +1,253 @@
+package lane
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMultiQueue(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc       string
+		shards     uint
+		wantShards uint
+	}{
+		{
+			desc:       "NewMultiQueue with a provided number of shards",
+			shards:     4,
+			wantShards: 4,
+		},
+		{
+			desc:       "NewMultiQueue with a zero number of shards",
+			shards:     0,
+			wantShards: 2 * uint(runtime.GOMAXPROCS(0)),
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			queue := NewMaxMultiQueue[int, int](tC.shards)
+
+			assert.Equal(t, tC.wantShards, queue.Shards())
+			assert.True(t, queue.Empty())
+		})
+	}
+}
+
+func TestMultiQueuePop(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc   string
+		queue  *MultiQueue[int, int]
+		strict bool
+	}{
+		{
+			desc:   "Pop from a single shard MultiQueue is strictly ordered",
+			queue:  NewMinMultiQueue[int, int](1),
+			strict: true,
+		},
+		{
+			desc:   "Pop from a sharded MultiQueue",
+			queue:  NewMinMultiQueue[int, int](8),
+			strict: false,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, gotOk := tC.queue.Pop()
+			assert.False(t, gotOk)
+
+			want := rand.Perm(1000)
+			for _, priority := range want {
+				tC.queue.Push(priority, priority)
+			}
+
+			assert.Equal(t, uint(len(want)), tC.queue.Size())
+
+			var got []int
+			for {
+				value, priority, ok := tC.queue.Pop()
+				if !ok {
+					break
+				}
+
+				assert.Equal(t, value, priority)
+				got = append(got, priority)
+			}
+
+			assert.True(t, tC.queue.Empty())
+			assert.Equal(t, tC.strict, slices.IsSorted(got))
+
+			// Ordering is relaxed, but no item gets lost.
+			slices.Sort(got)
+			slices.Sort(want)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestMultiQueueRankError(t *testing.T) {
+	t.Parallel()
+
+	const (
+		shards = 8
+		items  = 4000
+	)
+
+	queue := NewMinMultiQueue[int, int](shards)
+
+	remaining := rand.Perm(items)
+	for _, priority := range remaining {
+		queue.Push(priority, priority)
+	}
+
+	slices.Sort(remaining)
+
+	// The rank error of a popped item is the number of remaining items
+	// which should have been served before it.
+	var total int
+	for range items {
+		_, priority, ok := queue.Pop()
+		assert.True(t, ok)
+
+		rank, found := slices.BinarySearch(remaining, priority)
+		assert.True(t, found)
+
+		total += rank
+		remaining = slices.Delete(remaining, rank, rank+1)
+	}
+
+	// The expected rank error is in O(shards): the bound is
+	// loose enough for the test not to be flaky.
+	mean := float64(total) / items
+	assert.Less(t, mean, float64(4*shards))
+}
+
+func TestMultiQueueConcurrentProducersAndConsumers(t *testing.T) {
+	t.Parallel()
+
+	const (
+		goroutines = 8
+		items      = 1000
+	)
+
+	queue := NewMaxMultiQueue[int, int](0)
+
+	var wg sync.WaitGroup
+	consumed := make([][]int, goroutines)
+
+	for g := 0; g < goroutines; g++ {
+		g := g
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < items; i++ {
+				queue.Push(g*items+i, i)
+
+				if value, _, ok := queue.Pop(); ok {
+					consumed[g] = append(consumed[g], value)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	var got []int
+	for _, values := range consumed {
+		got = append(got, values...)
+	}
+
+	for {
+		value, _, ok := queue.Pop()
+		if !ok {
+			break
+		}
+
+		got = append(got, value)
+	}
+
+	slices.Sort(got)
+
+	want := make([]int, goroutines*items)
+	for i := range want {
+		want[i] = i
+	}
+
+	assert.Equal(t, want, got)
+	assert.Equal(t, uint(0), queue.Size())
+}
+
+// priorityQueueBenchmarker is the set of operations shared by PriorityQueue
+// and MultiQueue that BenchmarkPriorityQueueContention exercises.
+type priorityQueueBenchmarker interface {
+	Pop() (int, int, bool)
+}
+
+// BenchmarkPriorityQueueContention compares the PriorityQueue with the
+// MultiQueue, with an increasing number of goroutines concurrently pushing
+// and popping items.
+func BenchmarkPriorityQueueContention(b *testing.B) {
+	implementations := []struct {
+		name  string
+		queue func() (priorityQueueBenchmarker, func(int, int))
+	}{
+		{
+			name: "mutex",
+			queue: func() (priorityQueueBenchmarker, func(int, int)) {
+				pqueue := NewMinPriorityQueue[int, int]()
+				return pqueue, func(value, priority int) { pqueue.Push(value, priority) }
+			},
+		},
+		{
+			name: "multiqueue",
+			queue: func() (priorityQueueBenchmarker, func(int, int)) {
+				queue := NewMinMultiQueue[int, int](0)
+				return queue, queue.Push
+			},
+		},
+	}
+
+	for _, impl := range implementations {
+		for _, parallelism := range []int{1, 4, 16, 64} {
+			impl, parallelism := impl, parallelism
+
+			b.Run(fmt.Sprintf("%s/goroutines=%dxGOMAXPROCS", impl.name, parallelism), func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetParallelism(parallelism)
+
+				queue, push := impl.queue()
+				for i := 0; i < 1<<12; i++ {
+					push(i, rand.Int())
+				}
+
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					for i := 0; pb.Next(); i++ {
+						push(i, rand.Int())
+						queue.Pop()
+					}
+				})
+			})
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package lane
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Observer is notified of the operations performed on a container, once
+// registered using its SetObserver method.
+//
+// Its methods are called while the container's lock is held: they should
+// return quickly, and must not call the container's methods.
+type Observer interface {
+	// Inserted is called once n items were inserted into the container,
+	// size being the container's resulting size.
+	Inserted(n, size uint)
+
+	// Removed is called once n items were removed from the container,
+	// size being the container's resulting size.
+	Removed(n, size uint)
+
+	// Dropped is called once n items were discarded by the container,
+	// such as a BoundDeque's overflow or shrink policy, size being the
+	// container's resulting size.
+	Dropped(n, size uint)
+
+	// Waited is called each time a goroutine stops waiting on the
+	// container, d being the time it spent waiting.
+	Waited(d time.Duration)
+}
+
+// observation notifies a container's optional Observer. When no Observer
+// is set, its methods return right away.
+//
+// Its methods expect the caller to hold the lock guarding the container
+// it belongs to.
+type observation struct {
+	observer Observer
+}
+
+// inserted notifies the Observer that n items were inserted.
+func (o *observation) inserted(n, size uint) {
+	if o.observer != nil && n > 0 {
+		o.observer.Inserted(n, size)
+	}
+}
+
+// removed notifies the Observer that n items were removed.
+func (o *observation) removed(n, size uint) {
+	if o.observer != nil && n > 0 {
+		o.observer.Removed(n, size)
+	}
+}
+
+// dropped notifies the Observer that n items were discarded.
+func (o *observation) dropped(n, size uint) {
+	if o.observer != nil && n > 0 {
+		o.observer.Dropped(n, size)
+	}
+}
+
+// wait parks the calling goroutine on the waiters list, like waitList.wait
+// does, and notifies the Observer of the time it spent waiting.
+func (o *observation) wait(ctx context.Context, waiters *waitList, mu sync.Locker) error {
+	if o.observer == nil {
+		return waiters.wait(ctx, mu)
+	}
+
+	start := time.Now()
+	err := waiters.wait(ctx, mu)
+	o.observer.Waited(time.Since(start))
+
+	return err
+}
+
+// Metrics is an Observer collecting counters about the activity of
+// the containers it observes. Every operation on Metrics is goroutine-safe.
+//
+// Metrics implements the expvar.Var interface, and can be exposed
+// using expvar.Publish.
+type Metrics struct {
+	in            atomic.Uint64
+	out           atomic.Uint64
+	drops         atomic.Uint64
+	size          atomic.Uint64
+	highWaterMark atomic.Uint64
+	waits         atomic.Uint64
+	waitTime      atomic.Int64
+}
+
+// MetricsSnapshot holds the counters collected by Metrics
+// at a given point in time.
+type MetricsSnapshot struct {
+	// In is the total number of inserted items.
+	In uint64 `json:"in"`
+
+	// Out is the total number of removed items.
+	Out uint64 `json:"out"`
+
+	// Drops is the total number of discarded items.
+	Drops uint64 `json:"drops"`
+
+	// Size is the observed container's size.
+	Size uint64 `json:"size"`
+
+	// HighWaterMark is the largest size the observed container reached.
+	HighWaterMark uint64 `json:"high_water_mark"`
+
+	// Waits is the number of times a goroutine waited on the container.
+	Waits uint64 `json:"waits"`
+
+	// WaitTime is the total time goroutines spent waiting on the container.
+	WaitTime time.Duration `json:"wait_time"`
+}
+
+// NewMetrics produces a new Metrics instance, with every counter at zero.
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// Inserted implements Observer.
+func (m *Metrics) Inserted(n, size uint) {
+	m.in.Add(uint64(n))
+	m.observeSize(size)
+}
+
+// Removed implements Observer.
+func (m *Metrics) Removed(n, size uint) {
+	m.out.Add(uint64(n))
+	m.observeSize(size)
+}
+
+// Dropped implements Observer.
+func (m *Metrics) Dropped(n, size uint) {
+	m.drops.Add(uint64(n))
+	m.observeSize(size)
+}
+
+// Waited implements Observer.
+func (m *Metrics) Waited(d time.Duration) {
+	m.waits.Add(1)
+	m.waitTime.Add(int64(d))
+}
+
+// Snapshot returns the current value of the collected counters.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	return MetricsSnapshot{
+		In:            m.in.Load(),
+		Out:           m.out.Load(),
+		Drops:         m.drops.Load(),
+		Size:          m.size.Load(),
+		HighWaterMark: m.highWaterMark.Load(),
+		Waits:         m.waits.Load(),
+		WaitTime:      time.Duration(m.waitTime.Load()),
+	}
+}
+
+// String returns the collected counters as a JSON object,
+// implementing the expvar.Var interface.
+func (m *Metrics) String() string {
+	data, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		return "{}"
+	}
+
+	return string(data)
+}
+
+// observeSize records the observed container's size,
+// and raises the high-water mark if necessary.
+func (m *Metrics) observeSize(size uint) {
+	m.size.Store(uint64(size))
+
+	for {
+		highWaterMark := m.highWaterMark.Load()
+		if uint64(size) <= highWaterMark || m.highWaterMark.CompareAndSwap(highWaterMark, uint64(size)) {
+			return
+		}
+	}
+}
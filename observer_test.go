@@ -0,0 +1,209 @@
+package lane
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ expvar.Var = (*Metrics)(nil)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		observe func(*Metrics)
+		want    MetricsSnapshot
+	}{
+		{
+			desc: "Deque operations",
+			observe: func(metrics *Metrics) {
+				deque := NewDeque[int]()
+				deque.SetObserver(metrics)
+
+				deque.Append(40)
+				deque.Prepend(41)
+				deque.AppendAll(42, 43)
+				deque.Pop()
+				deque.ShiftN(2)
+				deque.Shift()
+				deque.Shift()
+			},
+			want: MetricsSnapshot{In: 4, Out: 4, Size: 0, HighWaterMark: 4},
+		},
+		{
+			desc: "Deque Clear",
+			observe: func(metrics *Metrics) {
+				deque := NewDeque[int]()
+				deque.SetObserver(metrics)
+
+				deque.AppendAll(40, 41, 42)
+				deque.Clear()
+			},
+			want: MetricsSnapshot{In: 3, Out: 3, Size: 0, HighWaterMark: 3},
+		},
+		{
+			desc: "BoundDeque with drop oldest policy",
+			observe: func(metrics *Metrics) {
+				deque := NewBoundDequeWithPolicy[int](2, OverflowDropOldest)
+				deque.SetObserver(metrics)
+
+				deque.AppendAll(40, 41, 42, 43)
+			},
+			want: MetricsSnapshot{In: 4, Drops: 2, Size: 2, HighWaterMark: 2},
+		},
+		{
+			desc: "BoundDeque with drop newest policy",
+			observe: func(metrics *Metrics) {
+				deque := NewBoundDequeWithPolicy[int](2, OverflowDropNewest)
+				deque.SetObserver(metrics)
+
+				deque.AppendAll(40, 41, 42, 43)
+			},
+			want: MetricsSnapshot{In: 2, Drops: 2, Size: 2, HighWaterMark: 2},
+		},
+		{
+			desc: "BoundDeque truncated by SetCapacity",
+			observe: func(metrics *Metrics) {
+				deque := NewBoundDeque[int](3)
+				deque.SetObserver(metrics)
+
+				deque.AppendAll(40, 41, 42)
+				deque.SetCapacity(1, ShrinkTruncateFront)
+			},
+			want: MetricsSnapshot{In: 3, Drops: 2, Size: 1, HighWaterMark: 3},
+		},
+		{
+			desc: "Queue operations",
+			observe: func(metrics *Metrics) {
+				queue := NewQueue[int]()
+				queue.SetObserver(metrics)
+
+				queue.EnqueueAll(40, 41)
+				queue.Dequeue()
+			},
+			want: MetricsSnapshot{In: 2, Out: 1, Size: 1, HighWaterMark: 2},
+		},
+		{
+			desc: "Stack operations",
+			observe: func(metrics *Metrics) {
+				stack := NewStack[int]()
+				stack.SetObserver(metrics)
+
+				stack.Push(40)
+				stack.PopN(2)
+			},
+			want: MetricsSnapshot{In: 1, Out: 1, Size: 0, HighWaterMark: 1},
+		},
+		{
+			desc: "RingDeque operations",
+			observe: func(metrics *Metrics) {
+				deque := NewRingDeque[int]()
+				deque.SetObserver(metrics)
+
+				deque.Append(40)
+				deque.Prepend(41)
+				deque.Pop()
+				deque.Pop()
+				deque.Pop()
+			},
+			want: MetricsSnapshot{In: 2, Out: 2, Size: 0, HighWaterMark: 2},
+		},
+		{
+			desc: "PriorityQueue operations",
+			observe: func(metrics *Metrics) {
+				pqueue := NewMaxPriorityQueue[string, int]()
+				pqueue.SetObserver(metrics)
+
+				handle := pqueue.Push("a", 1)
+				_, _ = pqueue.PushAll([]string{"b", "c"}, []int{2, 2})
+				pqueue.Remove(handle)
+				pqueue.Pop()
+				pqueue.PopN(2)
+			},
+			want: MetricsSnapshot{In: 3, Out: 3, Size: 0, HighWaterMark: 3},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			metrics := NewMetrics()
+			tC.observe(metrics)
+
+			assert.Equal(t, tC.want, metrics.Snapshot())
+		})
+	}
+}
+
+func TestMetricsWaited(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewMetrics()
+
+	deque := NewDeque[int]()
+	deque.SetObserver(metrics)
+
+	consumed := make(chan int)
+	go func() {
+		item, _ := deque.ShiftWait(context.Background())
+		consumed <- item
+	}()
+
+	assert.Eventually(t, func() bool { return waiting(deque) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+
+	deque.Append(42)
+	assert.Equal(t, 42, <-consumed)
+
+	snapshot := metrics.Snapshot()
+	assert.Equal(t, uint64(1), snapshot.Waits)
+	assert.GreaterOrEqual(t, snapshot.WaitTime, 10*time.Millisecond)
+}
+
+func TestMetricsString(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewMetrics()
+	metrics.Inserted(2, 2)
+	metrics.Removed(1, 1)
+
+	var got MetricsSnapshot
+	assert.NoError(t, json.Unmarshal([]byte(metrics.String()), &got))
+	assert.Equal(t, MetricsSnapshot{In: 2, Out: 1, Size: 1, HighWaterMark: 2}, got)
+}
+
+func TestSetObserverNil(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewMetrics()
+
+	deque := NewDeque[int]()
+	deque.SetObserver(metrics)
+	deque.Append(40)
+
+	// Unregistered observers are not notified anymore.
+	deque.SetObserver(nil)
+	deque.Append(41)
+
+	assert.Equal(t, uint64(1), metrics.Snapshot().In)
+}
+
+func BenchmarkDequeAppendObserved(b *testing.B) {
+	b.ReportAllocs()
+
+	deque := NewDeque[int]()
+	deque.SetObserver(NewMetrics())
+
+	for i := 0; i < b.N; i++ {
+		deque.Append(i)
+	}
+}
@@ -1,6 +1,9 @@
 package lane
 
 import (
+	"encoding/json"
+	"errors"
+	"iter"
 	"sync"
 
 	"golang.org/x/exp/constraints"
@@ -11,19 +14,86 @@ import (
 // It can either be min (ascending) or max (descending)
 // oriented/ordered. Its type parameters `T` and `P`, respectively
 // specify the underlying value type and the underlying priority type.
+// Priorities can be of any type, as long as the PriorityQueue is
+// provided with a heuristic to compare them.
 //
 // Every operation on PriorityQueues are goroutine-safe.
-type PriorityQueue[T any, P constraints.Ordered] struct {
+type PriorityQueue[T any, P any] struct {
 	sync.RWMutex
 	items      []*priorityQueueItem[T, P]
 	itemCount  uint
 	comparator func(lhs, rhs P) bool
+
+	// stable defines whether items of equal priorities are served in
+	// insertion order, in which case sequence numbers items as they are pushed.
+	stable   bool
+	sequence uint64
+
+	// arity is the number of children of each node of the heap.
+	arity uint
+
+	// observer is notified of the operations performed on the PriorityQueue.
+	observer observation
+}
+
+// PriorityQueueOption configures a PriorityQueue at construction.
+type PriorityQueueOption func(*priorityQueueOptions)
+
+// priorityQueueOptions holds the configuration of a PriorityQueue.
+type priorityQueueOptions struct {
+	stable bool
+	arity  uint
+}
+
+// defaultArity is the arity of a PriorityQueue's heap, unless
+// configured otherwise using WithArity.
+const defaultArity = 2
+
+// WithStableOrdering makes a PriorityQueue serve items of equal priorities
+// in the order they were pushed, First In First Out.
+//
+// By default, the order in which items of equal priorities are served
+// is unspecified.
+func WithStableOrdering() PriorityQueueOption {
+	return func(options *priorityQueueOptions) {
+		options.stable = true
+	}
+}
+
+// WithArity makes a PriorityQueue store its items in a d-ary heap, whose
+// nodes have d children, rather than in a binary heap. Arities lower than
+// 2 are ignored.
+//
+// A higher arity makes the heap shallower, and its nodes' children
+// contiguous in memory: pushing items gets faster, as it takes fewer steps
+// to restore the heap ordering, while popping them compares more children
+// at each step. On large PriorityQueues, a 4-ary heap tends to outperform
+// a binary one.
+func WithArity(d uint) PriorityQueueOption {
+	return func(options *priorityQueueOptions) {
+		if d >= 2 {
+			options.arity = d
+		}
+	}
 }
 
 // NewPriorityQueue instantiates a new PriorityQueue with the provided comparison heuristic.
 // The package defines the `Max` and `Min` heuristic to define a max-oriented or
 // min-oriented heuristics, respectively.
-func NewPriorityQueue[T any, P constraints.Ordered](heuristic func(lhs, rhs P) bool) *PriorityQueue[T, P] {
+//
+// The heuristic reports whether `rhs` should be served before `lhs`. It allows
+// priorities of any type, such as composite structs or time.Time values:
+//
+//	// Serve the earliest deadline first.
+//	pq := NewPriorityQueue[string](func(lhs, rhs time.Time) bool {
+//		return rhs.Before(lhs)
+//	})
+func NewPriorityQueue[T any, P any](heuristic func(lhs, rhs P) bool, options ...PriorityQueueOption) *PriorityQueue[T, P] {
+	opts := priorityQueueOptions{arity: defaultArity}
+	for _, option := range options {
+		option(&opts)
+	}
+
 	items := make([]*priorityQueueItem[T, P], 1)
 	items[0] = nil
 
@@ -31,17 +101,19 @@ func NewPriorityQueue[T any, P constraints.Ordered](heuristic func(lhs, rhs P) b
 		items:      items,
 		itemCount:  0,
 		comparator: heuristic,
+		stable:     opts.stable,
+		arity:      opts.arity,
 	}
 }
 
 // NewMaxPriorityQueue instantiates a new maximum oriented PriorityQueue.
-func NewMaxPriorityQueue[T any, P constraints.Ordered]() *PriorityQueue[T, P] {
-	return NewPriorityQueue[T](Maximum[P])
+func NewMaxPriorityQueue[T any, P constraints.Ordered](options ...PriorityQueueOption) *PriorityQueue[T, P] {
+	return NewPriorityQueue[T](Maximum[P], options...)
 }
 
 // NewMinPriorityQueue instantiates a new minimum oriented PriorityQueue.
-func NewMinPriorityQueue[T any, P constraints.Ordered]() *PriorityQueue[T, P] {
-	return NewPriorityQueue[T](Minimum[P])
+func NewMinPriorityQueue[T any, P constraints.Ordered](options ...PriorityQueueOption) *PriorityQueue[T, P] {
+	return NewPriorityQueue[T](Minimum[P], options...)
 }
 
 // Maximum returns whether `rhs` is greater than `lhs`.
@@ -60,16 +132,125 @@ func Minimum[T constraints.Ordered](lhs, rhs T) bool {
 	return lhs > rhs
 }
 
+// PriorityQueueHandle references an item pushed in a PriorityQueue.
+//
+// It allows to update the item's priority, or to remove it from the
+// PriorityQueue, for as long as the item remains in it.
+type PriorityQueueHandle[T any, P any] struct {
+	item *priorityQueueItem[T, P]
+}
+
+// Value returns the value of the item referenced by the handle.
+func (h PriorityQueueHandle[T, P]) Value() T {
+	return h.item.value
+}
+
 // Push inserts the value in the PriorityQueue with the provided priority
 // in at most *O(log n)* time complexity.
-func (pq *PriorityQueue[T, P]) Push(value T, priority P) {
+//
+// The returned handle can be used to update the item's priority, or to
+// remove it from the PriorityQueue later on.
+func (pq *PriorityQueue[T, P]) Push(value T, priority P) PriorityQueueHandle[T, P] {
 	item := newPriorityQueueItem(value, priority)
 
 	pq.Lock()
 	defer pq.Unlock()
-	pq.items = append(pq.items, item)
-	pq.itemCount++
+
+	pq.append(item)
 	pq.swim(pq.size())
+	pq.observer.inserted(1, pq.size())
+
+	return PriorityQueueHandle[T, P]{item: item}
+}
+
+// ErrPrioritiesMismatch is returned when pushing several values into a
+// PriorityQueue along with a different number of priorities.
+var ErrPrioritiesMismatch = errors.New("lane: values and priorities differ in number")
+
+// PushAll inserts values in the PriorityQueue, each with the priority found
+// at the same index in priorities. It returns the items' handles, in the
+// order of values, as Push does.
+//
+// If values and priorities differ in length, PushAll returns
+// ErrPrioritiesMismatch, and leaves the PriorityQueue untouched.
+//
+// When the values outnumber the items already held by the PriorityQueue, the
+// heap is rebuilt in a single pass, in *O(n)* time complexity. Otherwise, each
+// value is inserted in at most *O(log n)* time complexity. In both cases, the
+// PriorityQueue's lock is only acquired once.
+func (pq *PriorityQueue[T, P]) PushAll(values []T, priorities []P) ([]PriorityQueueHandle[T, P], error) {
+	if len(values) != len(priorities) {
+		return nil, ErrPrioritiesMismatch
+	}
+
+	items := make([]*priorityQueueItem[T, P], len(values))
+	handles := make([]PriorityQueueHandle[T, P], len(values))
+	for i, value := range values {
+		items[i] = newPriorityQueueItem(value, priorities[i])
+		handles[i] = PriorityQueueHandle[T, P]{item: items[i]}
+	}
+
+	pq.Lock()
+	defer pq.Unlock()
+
+	heapify := uint(len(items)) > pq.size()
+
+	for _, item := range items {
+		pq.append(item)
+
+		if !heapify {
+			pq.swim(pq.size())
+		}
+	}
+
+	if heapify {
+		pq.heapify()
+	}
+
+	pq.observer.inserted(uint(len(items)), pq.size())
+
+	return handles, nil
+}
+
+// UpdatePriority changes the priority of the item referenced by handle, and
+// restores the PriorityQueue's ordering in at most *O(log n)* time complexity.
+//
+// It returns false if the item is not held by the PriorityQueue, either
+// because it was already removed from it, or because it was pushed in
+// another PriorityQueue.
+func (pq *PriorityQueue[T, P]) UpdatePriority(handle PriorityQueueHandle[T, P], priority P) bool {
+	pq.Lock()
+	defer pq.Unlock()
+
+	if !pq.contains(handle.item) {
+		return false
+	}
+
+	handle.item.priority = priority
+	pq.swim(handle.item.index)
+	pq.sink(handle.item.index)
+
+	return true
+}
+
+// Remove removes the item referenced by handle from the PriorityQueue in
+// at most *O(log n)* time complexity, and returns its value and priority.
+//
+// If the item is not held by the PriorityQueue, either because it was
+// already removed from it, or because it was pushed in another
+// PriorityQueue, ok is false.
+func (pq *PriorityQueue[T, P]) Remove(handle PriorityQueueHandle[T, P]) (value T, priority P, ok bool) {
+	pq.Lock()
+	defer pq.Unlock()
+
+	if !pq.contains(handle.item) {
+		return
+	}
+
+	item := pq.removeAt(handle.item.index)
+	pq.observer.removed(1, pq.size())
+
+	return item.value, item.priority, true
 }
 
 // Pop and return the highest or lowest priority item (depending on the
@@ -84,11 +265,8 @@ func (pq *PriorityQueue[T, P]) Pop() (value T, priority P, ok bool) {
 		return
 	}
 
-	max := pq.items[1]
-	pq.exch(1, pq.size())
-	pq.items = pq.items[0:pq.size()]
-	pq.itemCount--
-	pq.sink(1)
+	max := pq.removeAt(1)
+	pq.observer.removed(1, pq.size())
 
 	value = max.value
 	priority = max.priority
@@ -97,6 +275,34 @@ func (pq *PriorityQueue[T, P]) Pop() (value T, priority P, ok bool) {
 	return
 }
 
+// PopN pops and returns up to n of the highest or lowest priority items
+// (depending on the comparison heuristic of your PriorityQueue), in the
+// order successive calls to Pop would have returned them, in at most
+// *O(n log n)* complexity.
+//
+// It only acquires the PriorityQueue's lock once.
+func (pq *PriorityQueue[T, P]) PopN(n uint) (values []T, priorities []P) {
+	pq.Lock()
+	defer pq.Unlock()
+
+	if n > pq.size() {
+		n = pq.size()
+	}
+
+	values = make([]T, 0, n)
+	priorities = make([]P, 0, n)
+
+	for ; n > 0; n-- {
+		item := pq.removeAt(1)
+		values = append(values, item.value)
+		priorities = append(priorities, item.priority)
+	}
+
+	pq.observer.removed(uint(len(values)), pq.size())
+
+	return values, priorities
+}
+
 // Head returns the highest or lowest priority item (depending on
 // the comparison heuristic of your PriorityQueue) from the PriorityQueue
 // in *O(1)* complexity.
@@ -130,19 +336,286 @@ func (pq *PriorityQueue[T, P]) Empty() bool {
 	return pq.size() == 0
 }
 
+// Clear removes all the items from the PriorityQueue in *O(n)* time
+// complexity. Handles to the removed items become invalid.
+func (pq *PriorityQueue[T, P]) Clear() {
+	pq.Lock()
+	defer pq.Unlock()
+
+	for _, item := range pq.items[1:] {
+		item.index = 0
+	}
+
+	pq.observer.removed(pq.size(), 0)
+
+	pq.items = make([]*priorityQueueItem[T, P], 1)
+	pq.itemCount = 0
+}
+
+// SetObserver registers observer to be notified of the operations performed
+// on the PriorityQueue, replacing any previously registered one. A nil
+// observer unregisters it.
+func (pq *PriorityQueue[T, P]) SetObserver(observer Observer) {
+	pq.Lock()
+	defer pq.Unlock()
+
+	pq.observer.observer = observer
+}
+
+// Clone returns a copy of the PriorityQueue, sharing its comparison heuristic
+// and options, in *O(n)* time complexity. Handles to the PriorityQueue's items
+// are not valid for the copy.
+func (pq *PriorityQueue[T, P]) Clone() *PriorityQueue[T, P] {
+	pq.RLock()
+	defer pq.RUnlock()
+
+	items := make([]*priorityQueueItem[T, P], len(pq.items))
+	for i, item := range pq.items[1:] {
+		clone := *item
+		items[i+1] = &clone
+	}
+
+	return &PriorityQueue[T, P]{
+		items:      items,
+		itemCount:  pq.itemCount,
+		comparator: pq.comparator,
+		stable:     pq.stable,
+		sequence:   pq.sequence,
+		arity:      pq.arity,
+	}
+}
+
+// ToSlice returns a copy of the PriorityQueue's values and priorities, in
+// the order they would be popped, in *O(n log n)* time complexity.
+func (pq *PriorityQueue[T, P]) ToSlice() (values []T, priorities []P) {
+	items := pq.snapshot()
+
+	values = make([]T, len(items))
+	priorities = make([]P, len(items))
+
+	for i, item := range items {
+		values[i], priorities[i] = item.value, item.priority
+	}
+
+	return values, priorities
+}
+
+// All returns an iterator over the PriorityQueue's values and priorities,
+// in the order they would be popped.
+//
+// The iterator walks over a snapshot of the PriorityQueue, taken when All
+// is called: the PriorityQueue can safely be modified while iterating.
+// Producing the snapshot has a *O(n log n)* time complexity.
+func (pq *PriorityQueue[T, P]) All() iter.Seq2[T, P] {
+	items := pq.snapshot()
+
+	return func(yield func(T, P) bool) {
+		for _, item := range items {
+			if !yield(item.value, item.priority) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the PriorityQueue's values and priorities,
+// in the reverse order they would be popped.
+//
+// The iterator walks over a snapshot of the PriorityQueue, taken when Backward
+// is called: the PriorityQueue can safely be modified while iterating.
+// Producing the snapshot has a *O(n log n)* time complexity.
+func (pq *PriorityQueue[T, P]) Backward() iter.Seq2[T, P] {
+	items := pq.snapshot()
+
+	return func(yield func(T, P) bool) {
+		for i := len(items) - 1; i >= 0; i-- {
+			if !yield(items[i].value, items[i].priority) {
+				return
+			}
+		}
+	}
+}
+
+// ErrNoHeuristic is returned when unmarshaling into a PriorityQueue
+// which was not instantiated with a comparison heuristic.
+var ErrNoHeuristic = errors.New("lane: PriorityQueue has no comparison heuristic")
+
+// priorityQueueEntry is a value along with its priority.
+type priorityQueueEntry[T any, P any] struct {
+	Value    T `json:"value"`
+	Priority P `json:"priority"`
+}
+
+// encodedPriorityQueueItem is the encoded form of a PriorityQueue's item.
+type encodedPriorityQueueItem[T any, P any] struct {
+	Value    T `json:"value"`
+	Priority P `json:"priority"`
+
+	// Rank is the item's insertion rank, starting at one, only encoded by
+	// stable PriorityQueues. It is zero when absent, as gob does not
+	// distinguish a zero value from an absent one.
+	Rank uint64 `json:"rank,omitempty"`
+}
+
+// MarshalJSON encodes the PriorityQueue's items as a JSON array of
+// objects holding their value and priority, and their insertion rank
+// on stable PriorityQueues, laid out as in the underlying heap.
+//
+// Decoding them with UnmarshalJSON, into a PriorityQueue of the same arity and
+// comparison heuristic, restores the exact same heap: items are then popped
+// in the same order, including items of equal priorities.
+func (pq *PriorityQueue[T, P]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pq.entries())
+}
+
+// UnmarshalJSON replaces the PriorityQueue's items with the ones of the
+// provided JSON array, as encoded by MarshalJSON.
+//
+// The comparison heuristic is not part of the encoding: the PriorityQueue
+// must have been instantiated beforehand, or UnmarshalJSON returns
+// ErrNoHeuristic. On stable PriorityQueues, items of equal priorities
+// lacking an insertion rank are served in the order they appear in the
+// array, after the ones holding one.
+func (pq *PriorityQueue[T, P]) UnmarshalJSON(data []byte) error {
+	var entries []encodedPriorityQueueItem[T, P]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	return pq.replace(entries)
+}
+
+// MarshalBinary encodes the PriorityQueue's items, laid out as in the
+// underlying heap, using gob.
+//
+// Decoding them with UnmarshalBinary, into a PriorityQueue of the same arity and
+// comparison heuristic, restores the exact same heap: items are then popped
+// in the same order, including items of equal priorities.
+func (pq *PriorityQueue[T, P]) MarshalBinary() ([]byte, error) {
+	return marshalGob(pq.entries())
+}
+
+// UnmarshalBinary replaces the PriorityQueue's items with the ones encoded
+// by MarshalBinary.
+//
+// The comparison heuristic is not part of the encoding: the PriorityQueue
+// must have been instantiated beforehand, or UnmarshalBinary returns
+// ErrNoHeuristic.
+func (pq *PriorityQueue[T, P]) UnmarshalBinary(data []byte) error {
+	entries, err := unmarshalGob[[]encodedPriorityQueueItem[T, P]](data)
+	if err != nil {
+		return err
+	}
+
+	return pq.replace(entries)
+}
+
+// entries returns the encoded form of the PriorityQueue's items,
+// laid out as in the underlying heap.
+func (pq *PriorityQueue[T, P]) entries() []encodedPriorityQueueItem[T, P] {
+	pq.RLock()
+	defer pq.RUnlock()
+
+	entries := make([]encodedPriorityQueueItem[T, P], pq.size())
+	for i, item := range pq.items[1:] {
+		entries[i] = encodedPriorityQueueItem[T, P]{Value: item.value, Priority: item.priority}
+
+		if pq.stable {
+			entries[i].Rank = item.sequence + 1
+		}
+	}
+
+	return entries
+}
+
+// replace replaces the PriorityQueue's items with the provided entries.
+func (pq *PriorityQueue[T, P]) replace(entries []encodedPriorityQueueItem[T, P]) error {
+	pq.Lock()
+	defer pq.Unlock()
+
+	if pq.comparator == nil {
+		return ErrNoHeuristic
+	}
+
+	pq.observer.removed(pq.size(), 0)
+
+	pq.items = make([]*priorityQueueItem[T, P], 1, len(entries)+1)
+	pq.itemCount = 0
+	pq.sequence = 0
+
+	// Entries lacking an insertion rank are numbered after
+	// the ones holding one.
+	for _, entry := range entries {
+		pq.sequence = max(pq.sequence, entry.Rank)
+	}
+
+	for _, entry := range entries {
+		item := newPriorityQueueItem(entry.Value, entry.Priority)
+
+		if pq.stable && entry.Rank > 0 {
+			item.sequence = entry.Rank - 1
+			pq.attach(item)
+		} else {
+			pq.append(item)
+		}
+	}
+
+	// Entries encoded by MarshalJSON or MarshalBinary are laid out as a
+	// valid heap, which heapify leaves untouched. It only moves entries
+	// otherwise laid out, such as hand-written ones, or ones encoded by a
+	// PriorityQueue of another arity or comparison heuristic.
+	pq.heapify()
+	pq.observer.inserted(pq.size(), pq.size())
+
+	return nil
+}
+
+// snapshot returns a copy of the PriorityQueue's items, in the order
+// they would be popped, by popping them out of a copy of its heap.
+func (pq *PriorityQueue[T, P]) snapshot() []priorityQueueItem[T, P] {
+	heap := pq.Clone()
+
+	// Popping happens outside of the critical section.
+	items := make([]priorityQueueItem[T, P], 0, heap.size())
+	for heap.size() > 0 {
+		items = append(items, *heap.removeAt(1))
+	}
+
+	return items
+}
+
+// heapify restores the heap ordering of all the PriorityQueue's items,
+// in *O(n)* time complexity.
+func (pq *PriorityQueue[T, P]) heapify() {
+	if pq.size() < 2 {
+		return
+	}
+
+	for k := pq.parent(pq.size()); k >= 1; k-- {
+		pq.sink(k)
+	}
+}
+
 func (pq *PriorityQueue[T, P]) swim(k uint) {
-	for k > 1 && pq.less(k/2, k) {
-		pq.exch(k/2, k)
-		k /= 2
+	for k > 1 && pq.less(pq.parent(k), k) {
+		pq.exch(pq.parent(k), k)
+		k = pq.parent(k)
 	}
 }
 
 func (pq *PriorityQueue[T, P]) sink(k uint) {
-	for 2*k <= pq.size() {
-		j := 2 * k
+	for {
+		first := pq.firstChild(k)
+		if first > pq.size() {
+			break
+		}
 
-		if j < pq.size() && pq.less(j, j+1) {
-			j++
+		// Select the child to be served first.
+		j := first
+		for child := first + 1; child < first+pq.arity && child <= pq.size(); child++ {
+			if pq.less(j, child) {
+				j = child
+			}
 		}
 
 		if !pq.less(k, j) {
@@ -154,6 +627,62 @@ func (pq *PriorityQueue[T, P]) sink(k uint) {
 	}
 }
 
+// parent returns the index of the parent of the node at index k,
+// the heap being rooted at index 1.
+func (pq *PriorityQueue[T, P]) parent(k uint) uint {
+	return (k-2)/pq.arity + 1
+}
+
+// firstChild returns the index of the first child of the node at
+// index k, the heap being rooted at index 1.
+func (pq *PriorityQueue[T, P]) firstChild(k uint) uint {
+	return pq.arity*(k-1) + 2
+}
+
+// append adds item at the end of the heap, without restoring
+// the heap ordering.
+func (pq *PriorityQueue[T, P]) append(item *priorityQueueItem[T, P]) {
+	if pq.stable {
+		item.sequence = pq.sequence
+		pq.sequence++
+	}
+
+	pq.attach(item)
+}
+
+// attach adds item at the end of the heap, keeping its sequence,
+// without restoring the heap ordering.
+func (pq *PriorityQueue[T, P]) attach(item *priorityQueueItem[T, P]) {
+	pq.items = append(pq.items, item)
+	pq.itemCount++
+	item.index = pq.size()
+}
+
+// removeAt removes and returns the item at index k of the heap,
+// and restores the heap ordering.
+func (pq *PriorityQueue[T, P]) removeAt(k uint) *priorityQueueItem[T, P] {
+	item := pq.items[k]
+	last := pq.size()
+
+	pq.exch(k, last)
+	pq.items[last] = nil // avoid memory leaks
+	pq.items = pq.items[0:last]
+	pq.itemCount--
+	item.index = 0
+
+	if k < last {
+		pq.swim(k)
+		pq.sink(k)
+	}
+
+	return item
+}
+
+// contains returns whether item is held by the PriorityQueue.
+func (pq *PriorityQueue[T, P]) contains(item *priorityQueueItem[T, P]) bool {
+	return item != nil && item.index > 0 && item.index <= pq.size() && pq.items[item.index] == item
+}
+
 // size is a private method that's not goroutine-safe.
 // It assumes the caller already has acquired a lock on the PriorityQueue.
 func (pq *PriorityQueue[T, P]) size() uint {
@@ -161,21 +690,41 @@ func (pq *PriorityQueue[T, P]) size() uint {
 }
 
 func (pq *PriorityQueue[T, P]) less(lhs, rhs uint) bool {
-	return pq.comparator(pq.items[lhs].priority, pq.items[rhs].priority)
+	lhsItem, rhsItem := pq.items[lhs], pq.items[rhs]
+
+	if pq.comparator(lhsItem.priority, rhsItem.priority) {
+		return true
+	}
+
+	if !pq.stable || pq.comparator(rhsItem.priority, lhsItem.priority) {
+		return false
+	}
+
+	// Priorities are equal: the item pushed last is served last.
+	return lhsItem.sequence > rhsItem.sequence
 }
 
 func (pq *PriorityQueue[T, P]) exch(lhs, rhs uint) {
 	pq.items[lhs], pq.items[rhs] = pq.items[rhs], pq.items[lhs]
+	pq.items[lhs].index = lhs
+	pq.items[rhs].index = rhs
 }
 
 // priorityQueueItem is the underlying PriorityQueue item container.
-type priorityQueueItem[T any, P constraints.Ordered] struct {
+type priorityQueueItem[T any, P any] struct {
 	value    T
 	priority P
+
+	// index is the item's position in the heap, or 0 if the
+	// item is not held by a PriorityQueue.
+	index uint
+
+	// sequence is the item's insertion rank in a stable PriorityQueue.
+	sequence uint64
 }
 
 // newPriorityQueue instantiates a new priorityQueueItem.
-func newPriorityQueueItem[T any, P constraints.Ordered](value T, priority P) *priorityQueueItem[T, P] {
+func newPriorityQueueItem[T any, P any](value T, priority P) *priorityQueueItem[T, P] {
 	return &priorityQueueItem[T, P]{
 		value:    value,
 		priority: priority,
@@ -1,7 +1,11 @@
 package lane
 
 import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -23,10 +27,10 @@ func TestPriorityQueuePush(t *testing.T) {
 				newPriorityQueueItem("a", 1),
 			},
 			wantItemCount: 1,
-			wantItems: []*priorityQueueItem[string, int]{
+			wantItems: indexed([]*priorityQueueItem[string, int]{
 				nil,
 				newPriorityQueueItem("a", 1),
-			},
+			}),
 		},
 		{
 			desc:      "Push on multiple values on max oriented PriorityQueue",
@@ -37,12 +41,12 @@ func TestPriorityQueuePush(t *testing.T) {
 				newPriorityQueueItem("c", 3),
 			},
 			wantItemCount: 3,
-			wantItems: []*priorityQueueItem[string, int]{
+			wantItems: indexed([]*priorityQueueItem[string, int]{
 				nil,
 				newPriorityQueueItem("c", 3),
 				newPriorityQueueItem("a", 1),
 				newPriorityQueueItem("b", 2),
-			},
+			}),
 		},
 		{
 			desc:      "Push on multiple values on min oriented PriorityQueue",
@@ -53,12 +57,12 @@ func TestPriorityQueuePush(t *testing.T) {
 				newPriorityQueueItem("c", 3),
 			},
 			wantItemCount: 3,
-			wantItems: []*priorityQueueItem[string, int]{
+			wantItems: indexed([]*priorityQueueItem[string, int]{
 				nil,
 				newPriorityQueueItem("a", 1),
 				newPriorityQueueItem("b", 2),
 				newPriorityQueueItem("c", 3),
-			},
+			}),
 		},
 	}
 
@@ -114,9 +118,9 @@ func TestPriorityQueuePop(t *testing.T) {
 			wantValue:     "",
 			wantPriority:  0,
 			wantItemCount: 0,
-			wantItems: []*priorityQueueItem[string, int]{
+			wantItems: indexed([]*priorityQueueItem[string, int]{
 				nil,
-			},
+			}),
 		},
 		{
 			desc:      "Pop from a filled max oriented PriorityQueue",
@@ -130,11 +134,11 @@ func TestPriorityQueuePop(t *testing.T) {
 			wantValue:     "c",
 			wantPriority:  3,
 			wantItemCount: 2,
-			wantItems: []*priorityQueueItem[string, int]{
+			wantItems: indexed([]*priorityQueueItem[string, int]{
 				nil,
 				newPriorityQueueItem("b", 2),
 				newPriorityQueueItem("a", 1),
-			},
+			}),
 		},
 		{
 			desc:      "Pop from a filled min oriented PriorityQueue",
@@ -148,11 +152,11 @@ func TestPriorityQueuePop(t *testing.T) {
 			wantValue:     "a",
 			wantPriority:  1,
 			wantItemCount: 2,
-			wantItems: []*priorityQueueItem[string, int]{
+			wantItems: indexed([]*priorityQueueItem[string, int]{
 				nil,
 				newPriorityQueueItem("b", 2),
 				newPriorityQueueItem("c", 3),
-			},
+			}),
 		},
 	}
 
@@ -212,9 +216,9 @@ func TestPriorityQueueHead(t *testing.T) {
 			wantValue:     "",
 			wantPriority:  0,
 			wantItemCount: 0,
-			wantItems: []*priorityQueueItem[string, int]{
+			wantItems: indexed([]*priorityQueueItem[string, int]{
 				nil,
-			},
+			}),
 		},
 		{
 			desc:      "Head of a filled max oriented PriorityQueue",
@@ -228,12 +232,12 @@ func TestPriorityQueueHead(t *testing.T) {
 			wantValue:     "c",
 			wantPriority:  3,
 			wantItemCount: 3,
-			wantItems: []*priorityQueueItem[string, int]{
+			wantItems: indexed([]*priorityQueueItem[string, int]{
 				nil,
 				newPriorityQueueItem("c", 3),
 				newPriorityQueueItem("a", 1),
 				newPriorityQueueItem("b", 2),
-			},
+			}),
 		},
 		{
 			desc:      "Head of a filled min oriented PriorityQueue",
@@ -247,12 +251,12 @@ func TestPriorityQueueHead(t *testing.T) {
 			wantValue:     "a",
 			wantPriority:  1,
 			wantItemCount: 3,
-			wantItems: []*priorityQueueItem[string, int]{
+			wantItems: indexed([]*priorityQueueItem[string, int]{
 				nil,
 				newPriorityQueueItem("a", 1),
 				newPriorityQueueItem("b", 2),
 				newPriorityQueueItem("c", 3),
-			},
+			}),
 		},
 	}
 
@@ -396,3 +400,730 @@ func BenchmarkPriorityQueueEmpty(b *testing.B) {
 		pqueue.Empty()
 	}
 }
+
+// indexed sets the heap index of each of the provided items to its
+// position in the slice, and returns it.
+func indexed[T any, P any](items []*priorityQueueItem[T, P]) []*priorityQueueItem[T, P] {
+	for i, item := range items {
+		if item != nil {
+			item.index = uint(i)
+		}
+	}
+
+	return items
+}
+
+func TestPriorityQueueUpdatePriority(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		heuristic    func(lhs, rhs int) bool
+		update       string
+		priority     int
+		wantOk       bool
+		wantPopOrder []string
+	}{
+		{
+			desc:         "Increasing an item's priority in a max oriented PriorityQueue moves it up",
+			heuristic:    Maximum[int],
+			update:       "a",
+			priority:     4,
+			wantOk:       true,
+			wantPopOrder: []string{"a", "c", "b"},
+		},
+		{
+			desc:         "Decreasing an item's priority in a max oriented PriorityQueue moves it down",
+			heuristic:    Maximum[int],
+			update:       "c",
+			priority:     0,
+			wantOk:       true,
+			wantPopOrder: []string{"b", "a", "c"},
+		},
+		{
+			desc:         "Decreasing an item's priority in a min oriented PriorityQueue moves it up",
+			heuristic:    Minimum[int],
+			update:       "c",
+			priority:     0,
+			wantOk:       true,
+			wantPopOrder: []string{"c", "a", "b"},
+		},
+		{
+			desc:         "Updating a removed item fails",
+			heuristic:    Maximum[int],
+			update:       "removed",
+			priority:     4,
+			wantOk:       false,
+			wantPopOrder: []string{"c", "b", "a"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.desc, func(t *testing.T) {
+			t.Parallel()
+
+			pqueue := NewPriorityQueue[string](testCase.heuristic)
+			handles := map[string]PriorityQueueHandle[string, int]{
+				"a":       pqueue.Push("a", 1),
+				"b":       pqueue.Push("b", 2),
+				"c":       pqueue.Push("c", 3),
+				"removed": pqueue.Push("removed", 5),
+			}
+			pqueue.Remove(handles["removed"])
+
+			gotOk := pqueue.UpdatePriority(handles[testCase.update], testCase.priority)
+
+			assert.Equal(t, testCase.wantOk, gotOk)
+			assert.Equal(t, testCase.wantPopOrder, popValues(pqueue))
+		})
+	}
+}
+
+func BenchmarkPriorityQueueUpdatePriority(b *testing.B) {
+	b.ReportAllocs()
+
+	pqueue := NewMaxPriorityQueue[int, int]()
+	handles := make([]PriorityQueueHandle[int, int], 1024)
+
+	for i := range handles {
+		handles[i] = pqueue.Push(i, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pqueue.UpdatePriority(handles[i%len(handles)], i)
+	}
+}
+
+func TestPriorityQueueRemove(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		remove       []string
+		wantValue    string
+		wantPriority int
+		wantOk       bool
+		wantPopOrder []string
+	}{
+		{
+			desc:         "Remove the head item",
+			remove:       []string{"d"},
+			wantValue:    "d",
+			wantPriority: 4,
+			wantOk:       true,
+			wantPopOrder: []string{"c", "b", "a"},
+		},
+		{
+			desc:         "Remove an inner item",
+			remove:       []string{"b"},
+			wantValue:    "b",
+			wantPriority: 2,
+			wantOk:       true,
+			wantPopOrder: []string{"d", "c", "a"},
+		},
+		{
+			desc:         "Remove the last item of the heap",
+			remove:       []string{"a"},
+			wantValue:    "a",
+			wantPriority: 1,
+			wantOk:       true,
+			wantPopOrder: []string{"d", "c", "b"},
+		},
+		{
+			desc:         "Remove an already removed item",
+			remove:       []string{"b", "b"},
+			wantOk:       false,
+			wantPopOrder: []string{"d", "c", "a"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.desc, func(t *testing.T) {
+			t.Parallel()
+
+			pqueue := NewMaxPriorityQueue[string, int]()
+			handles := map[string]PriorityQueueHandle[string, int]{
+				"d": pqueue.Push("d", 4),
+				"c": pqueue.Push("c", 3),
+				"b": pqueue.Push("b", 2),
+				"a": pqueue.Push("a", 1),
+			}
+
+			var (
+				gotValue    string
+				gotPriority int
+				gotOk       bool
+			)
+
+			for _, value := range testCase.remove {
+				gotValue, gotPriority, gotOk = pqueue.Remove(handles[value])
+			}
+
+			assert.Equal(t, testCase.wantOk, gotOk)
+			assert.Equal(t, testCase.wantValue, gotValue)
+			assert.Equal(t, testCase.wantPriority, gotPriority)
+			assert.Equal(t, testCase.wantPopOrder, popValues(pqueue))
+		})
+	}
+}
+
+func TestPriorityQueueHandleFromAnotherQueue(t *testing.T) {
+	t.Parallel()
+
+	pqueue := NewMaxPriorityQueue[string, int]()
+	other := NewMaxPriorityQueue[string, int]()
+
+	pqueue.Push("a", 1)
+	handle := other.Push("b", 2)
+
+	_, _, gotRemoveOk := pqueue.Remove(handle)
+	gotUpdateOk := pqueue.UpdatePriority(handle, 3)
+
+	assert.False(t, gotRemoveOk)
+	assert.False(t, gotUpdateOk)
+	assert.Equal(t, []string{"a"}, popValues(pqueue))
+	assert.Equal(t, []string{"b"}, popValues(other))
+}
+
+// popValues pops every item out of pqueue, and returns their values in order.
+func popValues[T any, P any](pqueue *PriorityQueue[T, P]) []T {
+	var values []T
+
+	for {
+		value, _, ok := pqueue.Pop()
+		if !ok {
+			return values
+		}
+
+		values = append(values, value)
+	}
+}
+
+func TestPriorityQueueCustomPriorities(t *testing.T) {
+	t.Parallel()
+
+	type job struct {
+		deadline     time.Time
+		tenantWeight int
+	}
+
+	now := time.Now()
+
+	// Serve the earliest deadline first, then the heaviest tenant.
+	pqueue := NewPriorityQueue[string](func(lhs, rhs job) bool {
+		if !lhs.deadline.Equal(rhs.deadline) {
+			return rhs.deadline.Before(lhs.deadline)
+		}
+
+		return lhs.tenantWeight < rhs.tenantWeight
+	})
+
+	pqueue.Push("later", job{deadline: now.Add(time.Hour), tenantWeight: 10})
+	pqueue.Push("light", job{deadline: now, tenantWeight: 1})
+	pqueue.Push("heavy", job{deadline: now, tenantWeight: 5})
+	pqueue.Push("earliest", job{deadline: now.Add(-time.Hour), tenantWeight: 0})
+
+	assert.Equal(t, []string{"earliest", "heavy", "light", "later"}, popValues(pqueue))
+}
+
+func TestPriorityQueueStableOrdering(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		pqueue       *PriorityQueue[string, int]
+		wantFirst    string
+		wantPopOrder []string
+	}{
+		{
+			desc:         "Stable max oriented PriorityQueue serves equal priorities in insertion order",
+			pqueue:       NewMaxPriorityQueue[string, int](WithStableOrdering()),
+			wantFirst:    "c1",
+			wantPopOrder: []string{"c2", "b1", "b2", "b3", "b4", "a1", "a2"},
+		},
+		{
+			desc:         "Stable min oriented PriorityQueue serves equal priorities in insertion order",
+			pqueue:       NewMinPriorityQueue[string, int](WithStableOrdering()),
+			wantFirst:    "a1",
+			wantPopOrder: []string{"a2", "b1", "b2", "b3", "b4", "c1", "c2"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.desc, func(t *testing.T) {
+			t.Parallel()
+
+			testCase.pqueue.Push("b1", 2)
+			testCase.pqueue.Push("a1", 1)
+			testCase.pqueue.Push("b2", 2)
+			testCase.pqueue.Push("c1", 3)
+			testCase.pqueue.Push("b3", 2)
+			testCase.pqueue.Push("a2", 1)
+			testCase.pqueue.Push("c2", 3)
+
+			// Interleave a Pop and a Push, to make sure the
+			// ordering holds across mutations of the heap.
+			gotFirst, _, _ := testCase.pqueue.Pop()
+			testCase.pqueue.Push("b4", 2)
+
+			assert.Equal(t, testCase.wantFirst, gotFirst)
+			assert.Equal(t, testCase.wantPopOrder, popValues(testCase.pqueue))
+		})
+	}
+}
+
+func TestPriorityQueueAll(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc           string
+		pqueue         *PriorityQueue[string, int]
+		pushItems      []*priorityQueueItem[string, int]
+		wantValues     []string
+		wantPriorities []int
+	}{
+		{
+			desc:   "Iterating over an empty PriorityQueue yields nothing",
+			pqueue: NewMaxPriorityQueue[string, int](),
+		},
+		{
+			desc:   "Iterating over a max oriented PriorityQueue yields items in popping order",
+			pqueue: NewMaxPriorityQueue[string, int](),
+			pushItems: []*priorityQueueItem[string, int]{
+				newPriorityQueueItem("b", 2),
+				newPriorityQueueItem("d", 4),
+				newPriorityQueueItem("a", 1),
+				newPriorityQueueItem("c", 3),
+			},
+			wantValues:     []string{"d", "c", "b", "a"},
+			wantPriorities: []int{4, 3, 2, 1},
+		},
+		{
+			desc:   "Iterating over a min oriented PriorityQueue yields items in popping order",
+			pqueue: NewMinPriorityQueue[string, int](),
+			pushItems: []*priorityQueueItem[string, int]{
+				newPriorityQueueItem("b", 2),
+				newPriorityQueueItem("d", 4),
+				newPriorityQueueItem("a", 1),
+				newPriorityQueueItem("c", 3),
+			},
+			wantValues:     []string{"a", "b", "c", "d"},
+			wantPriorities: []int{1, 2, 3, 4},
+		},
+		{
+			desc:   "Iterating over a stable PriorityQueue yields equal priorities in insertion order",
+			pqueue: NewMaxPriorityQueue[string, int](WithStableOrdering()),
+			pushItems: []*priorityQueueItem[string, int]{
+				newPriorityQueueItem("a", 1),
+				newPriorityQueueItem("b", 1),
+				newPriorityQueueItem("c", 1),
+				newPriorityQueueItem("d", 1),
+			},
+			wantValues:     []string{"a", "b", "c", "d"},
+			wantPriorities: []int{1, 1, 1, 1},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.desc, func(t *testing.T) {
+			t.Parallel()
+
+			for _, item := range testCase.pushItems {
+				testCase.pqueue.Push(item.value, item.priority)
+			}
+
+			var (
+				gotValues     []string
+				gotPriorities []int
+				gotBackward   []string
+			)
+
+			for value, priority := range testCase.pqueue.All() {
+				gotValues = append(gotValues, value)
+				gotPriorities = append(gotPriorities, priority)
+			}
+
+			for value := range testCase.pqueue.Backward() {
+				gotBackward = append([]string{value}, gotBackward...)
+			}
+
+			assert.Equal(t, testCase.wantValues, gotValues)
+			assert.Equal(t, testCase.wantPriorities, gotPriorities)
+			assert.Equal(t, testCase.wantValues, gotBackward)
+			assert.Equal(t, uint(len(testCase.pushItems)), testCase.pqueue.Size())
+		})
+	}
+}
+
+func TestPriorityQueueAllEqualPriorities(t *testing.T) {
+	t.Parallel()
+
+	for seed := uint64(0); seed < 50; seed++ {
+		pqueue := equalPrioritiesPriorityQueue(seed)
+
+		var gotValues, gotBackward []int
+
+		for value := range pqueue.All() {
+			gotValues = append(gotValues, value)
+		}
+
+		for value := range pqueue.Backward() {
+			gotBackward = append([]int{value}, gotBackward...)
+		}
+
+		// Items of equal priorities are yielded in the
+		// order they are popped, even on unstable queues.
+		wantValues, _ := pqueue.PopN(pqueue.Size())
+
+		assert.Equal(t, wantValues, gotValues)
+		assert.Equal(t, wantValues, gotBackward)
+	}
+}
+
+// equalPrioritiesPriorityQueue returns an unstable PriorityQueue holding
+// many items of equal priorities, pushed and popped in an order randomized
+// by seed.
+func equalPrioritiesPriorityQueue(seed uint64) *PriorityQueue[int, int] {
+	random := rand.New(rand.NewPCG(seed, 42))
+	pqueue := NewMaxPriorityQueue[int, int]()
+
+	for i := 0; i < 30; i++ {
+		pqueue.Push(i, random.IntN(4))
+	}
+
+	pqueue.PopN(5)
+
+	return pqueue
+}
+
+func TestPriorityQueuePushAll(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		pqueue       *PriorityQueue[int, int]
+		preexisting  []int
+		values       []int
+		priorities   []int
+		wantErr      error
+		wantPopOrder []int
+	}{
+		{
+			desc:         "PushAll on empty PriorityQueue rebuilds the heap",
+			pqueue:       NewMaxPriorityQueue[int, int](),
+			values:       []int{3, 1, 4, 1, 5, 9, 2, 6},
+			priorities:   []int{3, 1, 4, 1, 5, 9, 2, 6},
+			wantPopOrder: []int{9, 6, 5, 4, 3, 2, 1, 1},
+		},
+		{
+			desc:         "PushAll of fewer values than held by the PriorityQueue inserts them one by one",
+			pqueue:       NewMinPriorityQueue[int, int](),
+			preexisting:  []int{3, 1, 4, 1, 5},
+			values:       []int{9, 2, 6},
+			priorities:   []int{9, 2, 6},
+			wantPopOrder: []int{1, 1, 2, 3, 4, 5, 6, 9},
+		},
+		{
+			desc:         "PushAll of more values than held by the PriorityQueue rebuilds the heap",
+			pqueue:       NewMinPriorityQueue[int, int](),
+			preexisting:  []int{9, 2},
+			values:       []int{3, 1, 4, 1, 5, 6},
+			priorities:   []int{3, 1, 4, 1, 5, 6},
+			wantPopOrder: []int{1, 1, 2, 3, 4, 5, 6, 9},
+		},
+		{
+			desc:         "PushAll uses the priority provided along each value",
+			pqueue:       NewMinPriorityQueue[int, int](),
+			values:       []int{10, 20, 30},
+			priorities:   []int{3, 1, 2},
+			wantPopOrder: []int{20, 30, 10},
+		},
+		{
+			desc:         "PushAll of values and priorities differing in number returns an error",
+			pqueue:       NewMinPriorityQueue[int, int](),
+			preexisting:  []int{1},
+			values:       []int{3, 2},
+			priorities:   []int{3},
+			wantErr:      ErrPrioritiesMismatch,
+			wantPopOrder: []int{1},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.desc, func(t *testing.T) {
+			t.Parallel()
+
+			for _, value := range testCase.preexisting {
+				testCase.pqueue.Push(value, value)
+			}
+
+			handles, gotErr := testCase.pqueue.PushAll(testCase.values, testCase.priorities)
+
+			assert.ErrorIs(t, gotErr, testCase.wantErr)
+
+			if testCase.wantErr == nil {
+				assert.Len(t, handles, len(testCase.values))
+
+				for i, handle := range handles {
+					assert.Equal(t, testCase.values[i], handle.Value())
+				}
+			}
+
+			assert.Equal(t, testCase.wantPopOrder, popValues(testCase.pqueue))
+		})
+	}
+}
+
+func TestPriorityQueuePushAllStableOrdering(t *testing.T) {
+	t.Parallel()
+
+	pqueue := NewMaxPriorityQueue[string, int](WithStableOrdering())
+	pqueue.Push("b1", 2)
+	_, err := pqueue.PushAll([]string{"a1", "b2", "c1", "a2", "b3"}, []int{1, 2, 3, 1, 2})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"c1", "b1", "b2", "b3", "a1", "a2"}, popValues(pqueue))
+}
+
+func BenchmarkPriorityQueuePushAll(b *testing.B) {
+	b.ReportAllocs()
+
+	values := make([]int, 1024)
+	for i := range values {
+		values[i] = i
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pqueue := NewMaxPriorityQueue[int, int]()
+		_, _ = pqueue.PushAll(values, values)
+	}
+}
+
+func TestPriorityQueuePopN(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc           string
+		n              uint
+		wantValues     []string
+		wantPriorities []int
+		wantSize       uint
+	}{
+		{
+			desc:           "PopN pops the n highest priority items",
+			n:              2,
+			wantValues:     []string{"c", "b"},
+			wantPriorities: []int{3, 2},
+			wantSize:       1,
+		},
+		{
+			desc:           "PopN with n exceeding the PriorityQueue's size empties it",
+			n:              5,
+			wantValues:     []string{"c", "b", "a"},
+			wantPriorities: []int{3, 2, 1},
+			wantSize:       0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.desc, func(t *testing.T) {
+			t.Parallel()
+
+			pqueue := NewMaxPriorityQueue[string, int]()
+			pqueue.Push("a", 1)
+			pqueue.Push("c", 3)
+			pqueue.Push("b", 2)
+
+			gotValues, gotPriorities := pqueue.PopN(testCase.n)
+
+			assert.Equal(t, testCase.wantValues, gotValues)
+			assert.Equal(t, testCase.wantPriorities, gotPriorities)
+			assert.Equal(t, testCase.wantSize, pqueue.Size())
+		})
+	}
+}
+
+func TestPriorityQueueClear(t *testing.T) {
+	t.Parallel()
+
+	pqueue := NewMaxPriorityQueue[string, int]()
+	handle := pqueue.Push("a", 1)
+	pqueue.Push("b", 2)
+
+	pqueue.Clear()
+
+	assert.True(t, pqueue.Empty())
+	assert.False(t, pqueue.UpdatePriority(handle, 3))
+
+	// Handles to cleared items remain invalid once
+	// new items take their place in the heap.
+	pqueue.Push("c", 3)
+
+	_, _, gotRemoveOk := pqueue.Remove(handle)
+	assert.False(t, gotRemoveOk)
+	assert.Equal(t, []string{"c"}, popValues(pqueue))
+}
+
+func TestPriorityQueueCloneAndToSlice(t *testing.T) {
+	t.Parallel()
+
+	pqueue := NewMaxPriorityQueue[string, int](WithStableOrdering())
+	pqueue.Push("b1", 2)
+	pqueue.Push("a", 1)
+	handle := pqueue.Push("c", 3)
+
+	clone := pqueue.Clone()
+	clone.Push("b2", 2)
+	pqueue.Remove(handle)
+
+	gotValues, gotPriorities := pqueue.ToSlice()
+	assert.Equal(t, []string{"b1", "a"}, gotValues)
+	assert.Equal(t, []int{2, 1}, gotPriorities)
+
+	gotValues, gotPriorities = clone.ToSlice()
+	assert.Equal(t, []string{"c", "b1", "b2", "a"}, gotValues)
+	assert.Equal(t, []int{3, 2, 2, 1}, gotPriorities)
+
+	// Handles are bound to the PriorityQueue which returned them.
+	assert.False(t, clone.UpdatePriority(handle, 0))
+}
+
+func TestPriorityQueueToSliceEqualPriorities(t *testing.T) {
+	t.Parallel()
+
+	for seed := uint64(0); seed < 50; seed++ {
+		pqueue := equalPrioritiesPriorityQueue(seed)
+
+		gotValues, gotPriorities := pqueue.ToSlice()
+		wantValues, wantPriorities := pqueue.PopN(pqueue.Size())
+
+		assert.Equal(t, wantValues, gotValues)
+		assert.Equal(t, wantPriorities, gotPriorities)
+	}
+}
+
+func TestPriorityQueueWithArity(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc  string
+		arity uint
+	}{
+		{desc: "Arity lower than 2 is ignored", arity: 0},
+		{desc: "Binary heap", arity: 2},
+		{desc: "Ternary heap", arity: 3},
+		{desc: "4-ary heap", arity: 4},
+		{desc: "8-ary heap", arity: 8},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			random := rand.New(rand.NewPCG(uint64(tC.arity), 42))
+			pqueue := NewMinPriorityQueue[int, int](WithArity(tC.arity))
+
+			var want []int
+			var handles []PriorityQueueHandle[int, int]
+
+			for i := 0; i < 1000; i++ {
+				priority := random.IntN(1000)
+				handles = append(handles, pqueue.Push(priority, priority))
+			}
+
+			// Updating and removing items exercises swimming and
+			// sinking items from the middle of the heap.
+			for i, handle := range handles {
+				switch i % 3 {
+				case 0:
+					priority := random.IntN(1000)
+					assert.True(t, pqueue.UpdatePriority(handle, priority))
+					want = append(want, priority)
+				case 1:
+					_, _, ok := pqueue.Remove(handle)
+					assert.True(t, ok)
+				default:
+					want = append(want, handle.Value())
+				}
+			}
+
+			bulk := make([]int, 2000)
+			for i := range bulk {
+				bulk[i] = random.IntN(1000)
+			}
+
+			// Pushing more items than held rebuilds the heap.
+			_, err := pqueue.PushAll(bulk, bulk)
+			assert.NoError(t, err)
+			want = append(want, bulk...)
+
+			got := make([]int, 0, len(want))
+			for {
+				_, priority, ok := pqueue.Pop()
+				if !ok {
+					break
+				}
+
+				got = append(got, priority)
+			}
+
+			slices.Sort(want)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func BenchmarkPriorityQueueArity(b *testing.B) {
+	const size = 1 << 16
+
+	for _, arity := range []uint{2, 4, 8} {
+		arity := arity
+
+		b.Run(fmt.Sprintf("arity=%d/Push", arity), func(b *testing.B) {
+			b.ReportAllocs()
+
+			pqueue := NewMinPriorityQueue[int, int](WithArity(arity))
+			for i := 0; i < size; i++ {
+				pqueue.Push(i, size-i)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				pqueue.Push(i, -i)
+			}
+		})
+
+		b.Run(fmt.Sprintf("arity=%d/PushPop", arity), func(b *testing.B) {
+			b.ReportAllocs()
+
+			random := rand.New(rand.NewPCG(1, 2))
+
+			pqueue := NewMinPriorityQueue[int, int](WithArity(arity))
+			for i := 0; i < size; i++ {
+				pqueue.Push(i, random.Int())
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				pqueue.Push(i, random.Int())
+				pqueue.Pop()
+			}
+		})
+	}
+}
@@ -1,5 +1,12 @@
 package lane
 
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"slices"
+)
+
 // Queue is a First In First Out data structure implementation.
 //
 // Built upon a Deque container, its API focuses on the following core
@@ -30,11 +37,30 @@ func (q *Queue[T]) Enqueue(item T) {
 	q.container.Prepend(item)
 }
 
+// EnqueueAll adds items at the back of the Queue, in order, in *O(k)* time
+// complexity, k being the number of items.
+func (q *Queue[T]) EnqueueAll(items ...T) {
+	q.container.PrependAll(items...)
+}
+
 // Dequeue removes and returns the Queue's front item in *O(1)* time complexity.
 func (q *Queue[T]) Dequeue() (item T, ok bool) {
 	return q.container.Pop()
 }
 
+// DequeueN removes and returns up to n items from the front of the Queue,
+// in the order they were enqueued, in *O(n)* time complexity.
+func (q *Queue[T]) DequeueN(n uint) []T {
+	return q.container.PopN(n)
+}
+
+// DequeueWait removes and returns the Queue's front item. If the Queue is
+// empty, it blocks until an item is enqueued, or until ctx is done, in which
+// case it returns the context's error.
+func (q *Queue[T]) DequeueWait(ctx context.Context) (item T, err error) {
+	return q.container.PopWait(ctx)
+}
+
 // Head returns the Queue's front queue item in *O(1)* time complexity.
 func (q *Queue[T]) Head() (item T, ok bool) {
 	return q.container.Last()
@@ -44,3 +70,102 @@ func (q *Queue[T]) Head() (item T, ok bool) {
 func (q *Queue[T]) Size() uint {
 	return q.container.Size()
 }
+
+// Empty returns whether the Queue is empty.
+func (q *Queue[T]) Empty() bool {
+	return q.container.Empty()
+}
+
+// SetObserver registers observer to be notified of the operations performed
+// on the Queue, replacing any previously registered one. A nil observer
+// unregisters it.
+func (q *Queue[T]) SetObserver(observer Observer) {
+	q.container.SetObserver(observer)
+}
+
+// Clear removes all the items from the Queue in *O(1)* time complexity.
+func (q *Queue[T]) Clear() {
+	q.container.Clear()
+}
+
+// Clone returns a copy of the Queue, in *O(n)* time complexity.
+func (q *Queue[T]) Clone() *Queue[T] {
+	return NewQueue(q.ToSlice()...)
+}
+
+// ToSlice returns a copy of the Queue's items, from its head to its back,
+// in *O(n)* time complexity.
+func (q *Queue[T]) ToSlice() []T {
+	items := q.container.snapshot()
+	slices.Reverse(items)
+
+	return items
+}
+
+// All returns an iterator over the Queue's items, from its head to its back:
+// in the order they would be dequeued.
+//
+// The iterator walks over a snapshot of the Queue, taken when All is called:
+// the Queue can safely be modified while iterating.
+func (q *Queue[T]) All() iter.Seq[T] {
+	return q.container.Backward()
+}
+
+// Backward returns an iterator over the Queue's items, from its back to its head.
+//
+// The iterator walks over a snapshot of the Queue, taken when Backward is called:
+// the Queue can safely be modified while iterating.
+func (q *Queue[T]) Backward() iter.Seq[T] {
+	return q.container.All()
+}
+
+// MarshalJSON encodes the Queue's items as a JSON array, from its head
+// to its back.
+func (q *Queue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.ToSlice())
+}
+
+// UnmarshalJSON replaces the Queue's items with the ones of the
+// provided JSON array, from its head to its back.
+func (q *Queue[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	q.replace(items)
+
+	return nil
+}
+
+// MarshalBinary encodes the Queue's items, from its head to its back,
+// using gob.
+func (q *Queue[T]) MarshalBinary() ([]byte, error) {
+	return marshalGob(q.ToSlice())
+}
+
+// UnmarshalBinary replaces the Queue's items with the ones encoded
+// by MarshalBinary.
+func (q *Queue[T]) UnmarshalBinary(data []byte) error {
+	items, err := unmarshalGob[[]T](data)
+	if err != nil {
+		return err
+	}
+
+	q.replace(items)
+
+	return nil
+}
+
+// replace replaces the Queue's items with the provided ones, from its
+// head to its back.
+func (q *Queue[T]) replace(items []T) {
+	// The Queue's head is the back of its container.
+	slices.Reverse(items)
+
+	if q.container == nil {
+		q.container = NewDeque[T]()
+	}
+
+	q.container.replace(items)
+}
@@ -1,7 +1,10 @@
 package lane
 
 import (
+	"context"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -238,3 +241,148 @@ func BenchmarkQueueHead(b *testing.B) {
 		queue.Head()
 	}
 }
+
+func TestQueueDequeueWait(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		queue     *Queue[int]
+		enqueue   []int
+		wantValue int
+		wantErr   error
+	}{
+		{
+			desc:      "DequeueWait on a filled Queue returns the head value",
+			queue:     NewQueue([]int{42, 41, 40}...),
+			wantValue: 42,
+		},
+		{
+			desc:      "DequeueWait on an empty Queue returns the first enqueued value",
+			queue:     NewQueue[int](),
+			enqueue:   []int{42, 41},
+			wantValue: 42,
+		},
+		{
+			desc:    "DequeueWait on an empty Queue returns the context error",
+			queue:   NewQueue[int](),
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			go func() {
+				for _, item := range tC.enqueue {
+					tC.queue.Enqueue(item)
+				}
+			}()
+
+			gotValue, gotErr := tC.queue.DequeueWait(ctx)
+
+			assert.ErrorIs(t, gotErr, tC.wantErr)
+			assert.Equal(t, tC.wantValue, gotValue)
+		})
+	}
+}
+
+func TestQueueAll(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		queue        *Queue[int]
+		wantAll      []int
+		wantBackward []int
+	}{
+		{
+			desc:  "iterating over an empty Queue yields nothing",
+			queue: NewQueue[int](),
+		},
+		{
+			desc:         "iterating over a filled Queue yields its items in dequeuing order",
+			queue:        NewQueue([]int{42, 41, 40}...),
+			wantAll:      []int{42, 41, 40},
+			wantBackward: []int{40, 41, 42},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tC.wantAll, slices.Collect(tC.queue.All()))
+			assert.Equal(t, tC.wantBackward, slices.Collect(tC.queue.Backward()))
+		})
+	}
+}
+
+func TestQueueBatchOperations(t *testing.T) {
+	t.Parallel()
+
+	queue := NewQueue([]int{40}...)
+	queue.EnqueueAll(41, 42, 43)
+
+	assert.Equal(t, []int{40, 41}, queue.DequeueN(2))
+	assert.Equal(t, []int{42, 43}, queue.DequeueN(5))
+	assert.Equal(t, []int{}, queue.DequeueN(1))
+}
+
+func TestQueueEmpty(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		queue     *Queue[int]
+		wantEmpty bool
+	}{
+		{
+			desc:      "Empty on an empty Queue",
+			queue:     NewQueue[int](),
+			wantEmpty: true,
+		},
+		{
+			desc:      "Empty on a filled Queue",
+			queue:     NewQueue([]int{42}...),
+			wantEmpty: false,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tC.wantEmpty, tC.queue.Empty())
+		})
+	}
+}
+
+func TestQueueClearCloneAndToSlice(t *testing.T) {
+	t.Parallel()
+
+	queue := NewQueue([]int{40, 41, 42}...)
+	clone := queue.Clone()
+
+	assert.Equal(t, []int{40, 41, 42}, queue.ToSlice())
+
+	queue.Clear()
+	clone.Enqueue(43)
+
+	assert.True(t, queue.Empty())
+	assert.Equal(t, []int{}, queue.ToSlice())
+	assert.Equal(t, []int{40, 41, 42, 43}, clone.ToSlice())
+
+	item, _ := clone.Dequeue()
+	assert.Equal(t, 40, item)
+}
@@ -0,0 +1,176 @@
+package lane
+
+import (
+	"errors"
+	"math/bits"
+	"sync"
+)
+
+// ErrNonMonotonePriority is returned when pushing an item into a RadixHeap
+// with a priority lower than the one of the last popped item.
+var ErrNonMonotonePriority = errors.New("lane: priority is lower than the last popped one")
+
+// radixHeapBuckets is the number of buckets of a RadixHeap: one for the items
+// sharing the last popped priority, and one per bit of a uint64 priority.
+const radixHeapBuckets = 65
+
+// RadixHeap is a minimum oriented priority queue, specialized for monotone
+// workloads, such as Dijkstra's algorithm or timers: the priority of the
+// items pushed into it is never lower than the one of the last popped item.
+//
+// Items are spread over buckets, according to the highest bit by which their
+// priority differs from the last popped one. Pushing an item happens in *O(1)*
+// time complexity, and popping it in amortized *O(log C)*, C being the range
+// of the priorities, as each item moves down to a lower bucket at most once
+// per bit of its priority. PriorityQueue, in comparison, pushes and pops
+// items in *O(log n)*, n being its size, but does not restrict priorities.
+//
+// Every operation on a RadixHeap is goroutine-safe.
+type RadixHeap[T any] struct {
+	sync.RWMutex
+
+	// buckets holds the items, the i-th bucket holding the items whose
+	// priority's highest bit differing from last is the i-th one. The first
+	// bucket holds the items whose priority is equal to last.
+	buckets [radixHeapBuckets][]radixHeapItem[T]
+
+	// last is the priority of the last popped item.
+	last uint64
+
+	size uint
+}
+
+// NewRadixHeap produces a new RadixHeap instance.
+func NewRadixHeap[T any]() *RadixHeap[T] {
+	return &RadixHeap[T]{}
+}
+
+// Push inserts the value in the RadixHeap with the provided priority
+// in *O(1)* time complexity.
+//
+// If priority is lower than the one of the last popped item, Push
+// returns ErrNonMonotonePriority, and leaves the RadixHeap untouched.
+func (h *RadixHeap[T]) Push(value T, priority uint64) error {
+	h.Lock()
+	defer h.Unlock()
+
+	if priority < h.last {
+		return ErrNonMonotonePriority
+	}
+
+	h.insert(radixHeapItem[T]{value: value, priority: priority})
+	h.size++
+
+	return nil
+}
+
+// Pop removes and returns the lowest priority item of the RadixHeap in
+// amortized *O(log C)* time complexity, C being the range of the priorities.
+func (h *RadixHeap[T]) Pop() (value T, priority uint64, ok bool) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.size == 0 {
+		return value, priority, false
+	}
+
+	if bucket := h.buckets[0]; len(bucket) > 0 {
+		item := bucket[len(bucket)-1]
+		bucket[len(bucket)-1] = radixHeapItem[T]{} // avoid memory leaks
+		h.buckets[0] = bucket[:len(bucket)-1]
+		h.size--
+
+		return item.value, item.priority, true
+	}
+
+	// The lowest priority item is held by the first non-empty bucket. It
+	// becomes the last popped item, and the bucket's other items move down
+	// to lower buckets, relative to its priority.
+	i, m := h.min()
+	bucket := h.buckets[i]
+	item := bucket[m]
+
+	h.last = item.priority
+
+	// Relative to the new last popped priority, the bucket's items all
+	// belong to lower buckets: the bucket can be emptied while they move.
+	for j, other := range bucket {
+		if j != m {
+			h.insert(other)
+		}
+	}
+
+	clear(bucket) // avoid memory leaks
+	h.buckets[i] = bucket[:0]
+
+	h.size--
+
+	return item.value, item.priority, true
+}
+
+// Head returns the lowest priority item of the RadixHeap in *O(1)* time
+// complexity if it shares the last popped item's priority, and in *O(k)*
+// otherwise, k being the number of items holding a priority of the same
+// magnitude.
+func (h *RadixHeap[T]) Head() (value T, priority uint64, ok bool) {
+	h.RLock()
+	defer h.RUnlock()
+
+	if h.size == 0 {
+		return value, priority, false
+	}
+
+	if bucket := h.buckets[0]; len(bucket) > 0 {
+		item := bucket[len(bucket)-1]
+		return item.value, item.priority, true
+	}
+
+	i, m := h.min()
+	item := h.buckets[i][m]
+
+	return item.value, item.priority, true
+}
+
+// Size returns the number of items present in the RadixHeap.
+func (h *RadixHeap[T]) Size() uint {
+	h.RLock()
+	defer h.RUnlock()
+
+	return h.size
+}
+
+// Empty returns whether the RadixHeap is empty.
+func (h *RadixHeap[T]) Empty() bool {
+	h.RLock()
+	defer h.RUnlock()
+
+	return h.size == 0
+}
+
+// insert adds item to the bucket matching its priority.
+func (h *RadixHeap[T]) insert(item radixHeapItem[T]) {
+	i := bits.Len64(item.priority ^ h.last)
+	h.buckets[i] = append(h.buckets[i], item)
+}
+
+// min returns the index of the first non-empty bucket, and the index of
+// its lowest priority item. The RadixHeap must not be empty.
+func (h *RadixHeap[T]) min() (bucket, item int) {
+	for len(h.buckets[bucket]) == 0 {
+		bucket++
+	}
+
+	for j, other := range h.buckets[bucket] {
+		if other.priority < h.buckets[bucket][item].priority {
+			item = j
+		}
+	}
+
+	return bucket, item
+}
+
+// radixHeapItem is the underlying RadixHeap item container.
+type radixHeapItem[T any] struct {
+	value    T
+	priority uint64
+}
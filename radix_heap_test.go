@@ -0,0 +1,166 @@
+package lane
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRadixHeapPush(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc       string
+		priorities []uint64
+		pops       int
+		priority   uint64
+		wantErr    error
+		wantSize   uint
+	}{
+		{
+			desc:     "Push to an empty RadixHeap",
+			priority: 0,
+			wantSize: 1,
+		},
+		{
+			desc:       "Push a priority lower than the held ones",
+			priorities: []uint64{42},
+			priority:   40,
+			wantSize:   2,
+		},
+		{
+			desc:       "Push the last popped priority",
+			priorities: []uint64{40, 42},
+			pops:       1,
+			priority:   40,
+			wantSize:   2,
+		},
+		{
+			desc:       "Push a priority lower than the last popped one",
+			priorities: []uint64{40, 42},
+			pops:       1,
+			priority:   39,
+			wantErr:    ErrNonMonotonePriority,
+			wantSize:   1,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			heap := NewRadixHeap[string]()
+			for _, priority := range tC.priorities {
+				assert.NoError(t, heap.Push("", priority))
+			}
+
+			for i := 0; i < tC.pops; i++ {
+				heap.Pop()
+			}
+
+			gotErr := heap.Push("", tC.priority)
+
+			assert.ErrorIs(t, gotErr, tC.wantErr)
+			assert.Equal(t, tC.wantSize, heap.Size())
+		})
+	}
+}
+
+func TestRadixHeapPop(t *testing.T) {
+	t.Parallel()
+
+	heap := NewRadixHeap[string]()
+
+	_, _, gotOk := heap.Pop()
+	assert.False(t, gotOk)
+
+	_, _, gotOk = heap.Head()
+	assert.False(t, gotOk)
+
+	assert.NoError(t, heap.Push("c", 1<<63))
+	assert.NoError(t, heap.Push("b", 2))
+	assert.NoError(t, heap.Push("a", 0))
+
+	for _, want := range []struct {
+		value    string
+		priority uint64
+	}{{"a", 0}, {"b", 2}, {"c", 1 << 63}} {
+		headValue, headPriority, headOk := heap.Head()
+		gotValue, gotPriority, gotOk := heap.Pop()
+
+		assert.Equal(t, want.value, gotValue)
+		assert.Equal(t, want.priority, gotPriority)
+		assert.True(t, gotOk)
+
+		// Head returns the item Pop removes next.
+		assert.Equal(t, gotValue, headValue)
+		assert.Equal(t, gotPriority, headPriority)
+		assert.True(t, headOk)
+	}
+
+	assert.True(t, heap.Empty())
+}
+
+func TestRadixHeapOrdering(t *testing.T) {
+	t.Parallel()
+
+	// Simulating a monotone workload, such as Dijkstra's algorithm, items
+	// are pushed with priorities derived from the last popped one.
+	random := rand.New(rand.NewPCG(1, 2))
+	heap := NewRadixHeap[int]()
+
+	var last uint64
+	var want []uint64
+
+	for i := 0; i < 10000; i++ {
+		if random.IntN(3) > 0 || len(want) == 0 {
+			priority := last + random.Uint64N(1000)
+			assert.NoError(t, heap.Push(i, priority))
+
+			want = append(want, priority)
+			slices.Sort(want)
+
+			continue
+		}
+
+		_, gotPriority, gotOk := heap.Pop()
+		assert.True(t, gotOk)
+		assert.Equal(t, want[0], gotPriority)
+
+		last = gotPriority
+		want = want[1:]
+	}
+
+	assert.Equal(t, uint(len(want)), heap.Size())
+}
+
+func BenchmarkRadixHeapPush(b *testing.B) {
+	b.ReportAllocs()
+
+	heap := NewRadixHeap[int]()
+
+	for i := 0; i < b.N; i++ {
+		_ = heap.Push(i, uint64(i))
+	}
+}
+
+func BenchmarkRadixHeapPushPop(b *testing.B) {
+	b.ReportAllocs()
+
+	random := rand.New(rand.NewPCG(1, 2))
+	heap := NewRadixHeap[int]()
+
+	for i := 0; i < 1<<16; i++ {
+		_ = heap.Push(i, random.Uint64N(1<<20))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, last, _ := heap.Pop()
+		_ = heap.Push(i, last+random.Uint64N(1<<20))
+	}
+}
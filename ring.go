@@ -0,0 +1,153 @@
+package lane
+
+// minRingCapacity is the capacity a ring allocates on its first insertion,
+// and below which it never shrinks.
+const minRingCapacity = 16
+
+// ring is a growable circular buffer, supporting insertion and removal at
+// both of its ends in amortized *O(1)* time complexity.
+//
+// Its capacity is always a power of two, so that positions can be wrapped
+// using a bit mask instead of a modulo operation. The buffer doubles its
+// capacity once full, and halves it once it is a quarter full.
+//
+// A ring is not goroutine-safe: it assumes the caller already has acquired
+// a lock on the data structure it backs.
+type ring[T any] struct {
+	buf  []T
+	head uint
+	len  uint
+}
+
+// newRing returns a ring holding items, in order.
+func newRing[T any](items ...T) *ring[T] {
+	r := &ring[T]{}
+
+	for _, item := range items {
+		r.pushBack(item)
+	}
+
+	return r
+}
+
+// Len returns the number of items stored in the ring.
+func (r *ring[T]) Len() uint {
+	return r.len
+}
+
+// pushBack inserts item at the back of the ring.
+func (r *ring[T]) pushBack(item T) {
+	r.grow()
+
+	r.buf[r.index(r.len)] = item
+	r.len++
+}
+
+// pushFront inserts item at the front of the ring.
+func (r *ring[T]) pushFront(item T) {
+	r.grow()
+
+	r.head = r.index(uint(len(r.buf)) - 1)
+	r.buf[r.head] = item
+	r.len++
+}
+
+// popBack removes and returns the back item of the ring.
+func (r *ring[T]) popBack() (item T, ok bool) {
+	if r.len == 0 {
+		return
+	}
+
+	r.len--
+	pos := r.index(r.len)
+	item, ok = r.buf[pos], true
+
+	var zero T
+	r.buf[pos] = zero // avoid memory leaks
+
+	r.shrink()
+
+	return
+}
+
+// popFront removes and returns the front item of the ring.
+func (r *ring[T]) popFront() (item T, ok bool) {
+	if r.len == 0 {
+		return
+	}
+
+	item, ok = r.buf[r.head], true
+
+	var zero T
+	r.buf[r.head] = zero // avoid memory leaks
+
+	r.head = r.index(1)
+	r.len--
+
+	r.shrink()
+
+	return
+}
+
+// front returns the front item of the ring.
+func (r *ring[T]) front() (item T, ok bool) {
+	if r.len == 0 {
+		return
+	}
+
+	return r.buf[r.head], true
+}
+
+// back returns the back item of the ring.
+func (r *ring[T]) back() (item T, ok bool) {
+	if r.len == 0 {
+		return
+	}
+
+	return r.buf[r.index(r.len-1)], true
+}
+
+// at returns the i-th item of the ring, starting from its front.
+func (r *ring[T]) at(i uint) T {
+	return r.buf[r.index(i)]
+}
+
+// index returns the buffer position of the i-th item, starting from the front.
+func (r *ring[T]) index(i uint) uint {
+	return (r.head + i) & (uint(len(r.buf)) - 1)
+}
+
+// grow doubles the ring's capacity if it is full.
+func (r *ring[T]) grow() {
+	switch {
+	case len(r.buf) == 0:
+		r.buf = make([]T, minRingCapacity)
+	case r.len == uint(len(r.buf)):
+		r.resize(2 * r.len)
+	}
+}
+
+// shrink halves the ring's capacity if it is no more than a quarter full.
+func (r *ring[T]) shrink() {
+	if len(r.buf) > minRingCapacity && r.len <= uint(len(r.buf))/4 {
+		r.resize(uint(len(r.buf)) / 2)
+	}
+}
+
+// resize moves the ring's items into a new buffer of the provided capacity.
+func (r *ring[T]) resize(capacity uint) {
+	buf := make([]T, capacity)
+
+	// The items might wrap around the end of the buffer, in which case
+	// they are copied in two steps.
+	end := r.head + r.len
+	if end > uint(len(r.buf)) {
+		end = uint(len(r.buf))
+	}
+
+	n := copy(buf, r.buf[r.head:end])
+	copy(buf[n:], r.buf[:r.len-uint(n)])
+
+	r.buf = buf
+	r.head = 0
+}
@@ -0,0 +1,215 @@
+package lane
+
+import (
+	"encoding/json"
+	"iter"
+	"sync"
+)
+
+// RingDeque implements a head-tail data structure, backed by a growable
+// circular buffer.
+//
+// It exposes the same operations as Deque, but stores its items in a
+// contiguous slice rather than a linked list. Its operations are thus
+// CPU-cache friendly, and only allocate when the buffer needs to grow or
+// shrink: every operation has an amortized *O(1)* time complexity.
+//
+// Every operation on a RingDeque is goroutine-safe.
+type RingDeque[T any] struct {
+	sync.RWMutex
+
+	// The underlying storage container.
+	container *ring[T]
+
+	// observer is notified of the operations performed on the RingDeque.
+	observer observation
+}
+
+// NewRingDeque produces a new RingDeque instance.
+func NewRingDeque[T any](items ...T) *RingDeque[T] {
+	return &RingDeque[T]{
+		container: newRing(items...),
+	}
+}
+
+// Append inserts item at the back of the RingDeque in an amortized *O(1)* time complexity.
+func (d *RingDeque[T]) Append(item T) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.container.pushBack(item)
+	d.observer.inserted(1, d.container.Len())
+}
+
+// Prepend inserts item at the RingDeque's front in an amortized *O(1)* time complexity.
+func (d *RingDeque[T]) Prepend(item T) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.container.pushFront(item)
+	d.observer.inserted(1, d.container.Len())
+}
+
+// Pop removes and returns the back element of the RingDeque in an amortized *O(1)* time complexity.
+func (d *RingDeque[T]) Pop() (item T, ok bool) {
+	d.Lock()
+	defer d.Unlock()
+
+	if item, ok = d.container.popBack(); ok {
+		d.observer.removed(1, d.container.Len())
+	}
+
+	return item, ok
+}
+
+// Shift removes and returns the front element of the RingDeque in an amortized *O(1)* time complexity.
+func (d *RingDeque[T]) Shift() (item T, ok bool) {
+	d.Lock()
+	defer d.Unlock()
+
+	if item, ok = d.container.popFront(); ok {
+		d.observer.removed(1, d.container.Len())
+	}
+
+	return item, ok
+}
+
+// First returns the first value stored in the RingDeque in *O(1)* time complexity.
+func (d *RingDeque[T]) First() (item T, ok bool) {
+	d.RLock()
+	defer d.RUnlock()
+
+	return d.container.front()
+}
+
+// Last returns the last value stored in the RingDeque in *O(1)* time complexity.
+func (d *RingDeque[T]) Last() (item T, ok bool) {
+	d.RLock()
+	defer d.RUnlock()
+
+	return d.container.back()
+}
+
+// Size returns the RingDeque's size.
+func (d *RingDeque[T]) Size() uint {
+	d.RLock()
+	defer d.RUnlock()
+
+	return d.container.Len()
+}
+
+// Empty checks if the RingDeque is empty.
+func (d *RingDeque[T]) Empty() bool {
+	d.RLock()
+	defer d.RUnlock()
+
+	return d.container.Len() == 0
+}
+
+// Clear removes all the elements from the RingDeque in *O(1)* time complexity.
+func (d *RingDeque[T]) Clear() {
+	d.Lock()
+	defer d.Unlock()
+
+	d.observer.removed(d.container.Len(), 0)
+	d.container = newRing[T]()
+}
+
+// SetObserver registers observer to be notified of the operations performed
+// on the RingDeque, replacing any previously registered one. A nil observer
+// unregisters it.
+func (d *RingDeque[T]) SetObserver(observer Observer) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.observer.observer = observer
+}
+
+// Clone returns a copy of the RingDeque, in *O(n)* time complexity.
+func (d *RingDeque[T]) Clone() *RingDeque[T] {
+	return NewRingDeque(d.snapshot()...)
+}
+
+// ToSlice returns a copy of the RingDeque's items, from front to back,
+// in *O(n)* time complexity.
+func (d *RingDeque[T]) ToSlice() []T {
+	return d.snapshot()
+}
+
+// All returns an iterator over the RingDeque's items, from front to back.
+//
+// The iterator walks over a snapshot of the RingDeque, taken when All is called:
+// the RingDeque can safely be modified while iterating.
+func (d *RingDeque[T]) All() iter.Seq[T] {
+	return forward(d.snapshot())
+}
+
+// Backward returns an iterator over the RingDeque's items, from back to front.
+//
+// The iterator walks over a snapshot of the RingDeque, taken when Backward is called:
+// the RingDeque can safely be modified while iterating.
+func (d *RingDeque[T]) Backward() iter.Seq[T] {
+	return backward(d.snapshot())
+}
+
+// MarshalJSON encodes the RingDeque's items as a JSON array, from front to back.
+func (d *RingDeque[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.snapshot())
+}
+
+// UnmarshalJSON replaces the RingDeque's items with the ones of the
+// provided JSON array, from front to back.
+func (d *RingDeque[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	d.replace(items)
+
+	return nil
+}
+
+// MarshalBinary encodes the RingDeque's items, from front to back, using gob.
+func (d *RingDeque[T]) MarshalBinary() ([]byte, error) {
+	return marshalGob(d.snapshot())
+}
+
+// UnmarshalBinary replaces the RingDeque's items with the ones encoded
+// by MarshalBinary.
+func (d *RingDeque[T]) UnmarshalBinary(data []byte) error {
+	items, err := unmarshalGob[[]T](data)
+	if err != nil {
+		return err
+	}
+
+	d.replace(items)
+
+	return nil
+}
+
+// replace replaces the RingDeque's items with the provided ones, from front to back.
+func (d *RingDeque[T]) replace(items []T) {
+	d.Lock()
+	defer d.Unlock()
+
+	if d.container != nil {
+		d.observer.removed(d.container.Len(), 0)
+	}
+
+	d.container = newRing(items...)
+	d.observer.inserted(d.container.Len(), d.container.Len())
+}
+
+// snapshot returns a copy of the RingDeque's items, from front to back.
+func (d *RingDeque[T]) snapshot() []T {
+	d.RLock()
+	defer d.RUnlock()
+
+	items := make([]T, d.container.Len())
+	for i := range items {
+		items[i] = d.container.at(uint(i))
+	}
+
+	return items
+}
@@ -0,0 +1,314 @@
+package lane
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingDequeAppend(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		deque     *RingDeque[int]
+		wantItems []int
+	}{
+		{
+			desc:      "append to empty deque inserts value",
+			deque:     NewRingDeque[int](),
+			wantItems: []int{42},
+		},
+		{
+			desc:      "append inserts value at the back",
+			deque:     NewRingDeque([]int{40, 41}...),
+			wantItems: []int{40, 41, 42},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tC.deque.Append(42)
+
+			assert.Equal(t, tC.wantItems, ringItems(tC.deque.container))
+		})
+	}
+}
+
+func TestRingDequePrepend(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		deque     *RingDeque[int]
+		wantItems []int
+	}{
+		{
+			desc:      "prepend to empty deque inserts value",
+			deque:     NewRingDeque[int](),
+			wantItems: []int{42},
+		},
+		{
+			desc:      "prepend inserts value at the front",
+			deque:     NewRingDeque([]int{43, 44}...),
+			wantItems: []int{42, 43, 44},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tC.deque.Prepend(42)
+
+			assert.Equal(t, tC.wantItems, ringItems(tC.deque.container))
+		})
+	}
+}
+
+func TestRingDequeRemoval(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		remove    func(*RingDeque[int]) (int, bool)
+		deque     *RingDeque[int]
+		wantValue int
+		wantOk    bool
+		wantItems []int
+	}{
+		{
+			desc:      "pop from empty deque",
+			remove:    (*RingDeque[int]).Pop,
+			deque:     NewRingDeque[int](),
+			wantItems: []int{},
+		},
+		{
+			desc:      "pop removes and returns the back value",
+			remove:    (*RingDeque[int]).Pop,
+			deque:     NewRingDeque([]int{40, 41, 42}...),
+			wantValue: 42,
+			wantOk:    true,
+			wantItems: []int{40, 41},
+		},
+		{
+			desc:      "shift from empty deque",
+			remove:    (*RingDeque[int]).Shift,
+			deque:     NewRingDeque[int](),
+			wantItems: []int{},
+		},
+		{
+			desc:      "shift removes and returns the front value",
+			remove:    (*RingDeque[int]).Shift,
+			deque:     NewRingDeque([]int{42, 41, 40}...),
+			wantValue: 42,
+			wantOk:    true,
+			wantItems: []int{41, 40},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			gotValue, gotOk := tC.remove(tC.deque)
+
+			assert.Equal(t, tC.wantValue, gotValue)
+			assert.Equal(t, tC.wantOk, gotOk)
+			assert.Equal(t, tC.wantItems, ringItems(tC.deque.container))
+		})
+	}
+}
+
+func TestRingDequeAccessors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		deque     *RingDeque[int]
+		wantFirst int
+		wantLast  int
+		wantOk    bool
+		wantSize  uint
+		wantEmpty bool
+	}{
+		{
+			desc:      "accessors on an empty deque",
+			deque:     NewRingDeque[int](),
+			wantEmpty: true,
+		},
+		{
+			desc:      "accessors on a filled deque",
+			deque:     NewRingDeque([]int{40, 41, 42}...),
+			wantFirst: 40,
+			wantLast:  42,
+			wantOk:    true,
+			wantSize:  3,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			gotFirst, gotFirstOk := tC.deque.First()
+			gotLast, gotLastOk := tC.deque.Last()
+
+			assert.Equal(t, tC.wantFirst, gotFirst)
+			assert.Equal(t, tC.wantOk, gotFirstOk)
+			assert.Equal(t, tC.wantLast, gotLast)
+			assert.Equal(t, tC.wantOk, gotLastOk)
+			assert.Equal(t, tC.wantSize, tC.deque.Size())
+			assert.Equal(t, tC.wantEmpty, tC.deque.Empty())
+		})
+	}
+}
+
+func TestRingDequeAll(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		deque        *RingDeque[int]
+		wantAll      []int
+		wantBackward []int
+	}{
+		{
+			desc:  "iterating over an empty deque yields nothing",
+			deque: NewRingDeque[int](),
+		},
+		{
+			desc:         "iterating over a filled deque yields its items",
+			deque:        NewRingDeque([]int{40, 41, 42}...),
+			wantAll:      []int{40, 41, 42},
+			wantBackward: []int{42, 41, 40},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tC.wantAll, slices.Collect(tC.deque.All()))
+			assert.Equal(t, tC.wantBackward, slices.Collect(tC.deque.Backward()))
+		})
+	}
+}
+
+func BenchmarkRingDequeAppend(b *testing.B) {
+	b.ReportAllocs()
+
+	deque := NewRingDeque[int]()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		deque.Append(i)
+	}
+}
+
+func BenchmarkRingDequePrepend(b *testing.B) {
+	b.ReportAllocs()
+
+	deque := NewRingDeque[int]()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		deque.Prepend(i)
+	}
+}
+
+func BenchmarkRingDequePop(b *testing.B) {
+	b.ReportAllocs()
+
+	deque := NewRingDeque[int]()
+
+	for i := 0; i < b.N; i++ {
+		deque.Append(i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		deque.Pop()
+	}
+}
+
+func BenchmarkRingDequeShift(b *testing.B) {
+	b.ReportAllocs()
+
+	deque := NewRingDeque[int]()
+
+	for i := 0; i < b.N; i++ {
+		deque.Append(i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		deque.Shift()
+	}
+}
+
+// dequeBenchmarker is the set of operations shared by Deque and RingDeque
+// that BenchmarkDequeImplementations exercises.
+type dequeBenchmarker interface {
+	Prepend(item int)
+	Pop() (int, bool)
+}
+
+// BenchmarkDequeImplementations compares the linked list backed Deque
+// with the RingDeque, on a FIFO workload keeping a steady amount of
+// items in the container.
+func BenchmarkDequeImplementations(b *testing.B) {
+	implementations := []struct {
+		name  string
+		deque func() dequeBenchmarker
+	}{
+		{name: "list", deque: func() dequeBenchmarker { return NewDeque[int]() }},
+		{name: "ring", deque: func() dequeBenchmarker { return NewRingDeque[int]() }},
+	}
+
+	for _, impl := range implementations {
+		impl := impl
+
+		b.Run(impl.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			deque := impl.deque()
+			for i := 0; i < 1024; i++ {
+				deque.Prepend(i)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				deque.Prepend(i)
+				deque.Pop()
+			}
+		})
+	}
+}
+
+func TestRingDequeClearCloneAndToSlice(t *testing.T) {
+	t.Parallel()
+
+	deque := NewRingDeque([]int{40, 41, 42}...)
+	clone := deque.Clone()
+
+	deque.Clear()
+	clone.Append(43)
+
+	assert.True(t, deque.Empty())
+	assert.Equal(t, []int{}, deque.ToSlice())
+	assert.Equal(t, []int{40, 41, 42, 43}, clone.ToSlice())
+}
@@ -0,0 +1,104 @@
+package lane
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingWrapsAround(t *testing.T) {
+	t.Parallel()
+
+	r := newRing[int]()
+
+	// Move the head close to the end of the buffer, so that
+	// subsequent insertions wrap around.
+	for i := 0; i < minRingCapacity-2; i++ {
+		r.pushBack(i)
+		r.popFront()
+	}
+
+	for i := 0; i < 4; i++ {
+		r.pushBack(i)
+	}
+
+	r.pushFront(-1)
+
+	assert.Equal(t, []int{-1, 0, 1, 2, 3}, ringItems(r))
+	assert.Equal(t, minRingCapacity, len(r.buf))
+}
+
+func TestRingGrowsAndShrinks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		push         int
+		pop          int
+		wantCapacity int
+	}{
+		{
+			desc:         "ring allocates its minimum capacity on first insertion",
+			push:         1,
+			wantCapacity: minRingCapacity,
+		},
+		{
+			desc:         "ring doubles its capacity once full",
+			push:         minRingCapacity + 1,
+			wantCapacity: 2 * minRingCapacity,
+		},
+		{
+			desc:         "ring halves its capacity once a quarter full",
+			push:         4 * minRingCapacity,
+			pop:          3 * minRingCapacity,
+			wantCapacity: 2 * minRingCapacity,
+		},
+		{
+			desc:         "ring does not shrink below its minimum capacity",
+			push:         minRingCapacity,
+			pop:          minRingCapacity,
+			wantCapacity: minRingCapacity,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			r := newRing[int]()
+			want := make([]int, 0, tC.push)
+
+			for i := 0; i < tC.push; i++ {
+				// Alternate between ends so that resizing
+				// has to deal with wrapped items.
+				if i%2 == 0 {
+					r.pushBack(i)
+					want = append(want, i)
+				} else {
+					r.pushFront(i)
+					want = append([]int{i}, want...)
+				}
+			}
+
+			for i := 0; i < tC.pop; i++ {
+				r.popFront()
+			}
+
+			assert.Equal(t, tC.wantCapacity, len(r.buf))
+			assert.Equal(t, want[tC.pop:], ringItems(r))
+		})
+	}
+}
+
+// ringItems returns the items of r, from front to back.
+func ringItems[T any](r *ring[T]) []T {
+	items := make([]T, 0, r.Len())
+
+	for i := uint(0); i < r.Len(); i++ {
+		items = append(items, r.at(i))
+	}
+
+	return items
+}
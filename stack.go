@@ -1,5 +1,11 @@
 package lane
 
+import (
+	"context"
+	"encoding/json"
+	"iter"
+)
+
 // Stack implements a Last In First Out data structure.
 //
 // Built upon a Deque container, it focuses its API on the following core
@@ -27,11 +33,30 @@ func (s *Stack[T]) Push(item T) {
 	s.container.Prepend(item)
 }
 
+// PushAll adds items on the top of the Stack, in order: the last item
+// ends up on the top of the Stack.
+func (s *Stack[T]) PushAll(items ...T) {
+	s.container.PrependAll(items...)
+}
+
 // Pop removes and returns the item on the top of the Stack.
 func (s *Stack[T]) Pop() (item T, ok bool) {
 	return s.container.Shift()
 }
 
+// PopN removes and returns up to n items from the top of the Stack,
+// in the order successive calls to Pop would have returned them.
+func (s *Stack[T]) PopN(n uint) []T {
+	return s.container.ShiftN(n)
+}
+
+// PopWait removes and returns the item on the top of the Stack. If the Stack is
+// empty, it blocks until an item is pushed, or until ctx is done, in which
+// case it returns the context's error.
+func (s *Stack[T]) PopWait(ctx context.Context) (item T, err error) {
+	return s.container.ShiftWait(ctx)
+}
+
 // Head returns the item on the top of the Stack.
 func (s *Stack[T]) Head() (item T, ok bool) {
 	return s.container.First()
@@ -41,3 +66,96 @@ func (s *Stack[T]) Head() (item T, ok bool) {
 func (s *Stack[T]) Size() uint {
 	return s.container.Size()
 }
+
+// Empty returns whether the Stack is empty.
+func (s *Stack[T]) Empty() bool {
+	return s.container.Empty()
+}
+
+// SetObserver registers observer to be notified of the operations performed
+// on the Stack, replacing any previously registered one. A nil observer
+// unregisters it.
+func (s *Stack[T]) SetObserver(observer Observer) {
+	s.container.SetObserver(observer)
+}
+
+// Clear removes all the items from the Stack in *O(1)* time complexity.
+func (s *Stack[T]) Clear() {
+	s.container.Clear()
+}
+
+// Clone returns a copy of the Stack, in *O(n)* time complexity.
+func (s *Stack[T]) Clone() *Stack[T] {
+	return NewStack(s.ToSlice()...)
+}
+
+// ToSlice returns a copy of the Stack's items, from its top to its bottom,
+// in *O(n)* time complexity.
+func (s *Stack[T]) ToSlice() []T {
+	return s.container.ToSlice()
+}
+
+// All returns an iterator over the Stack's items, from its top to its bottom:
+// in the order they would be popped.
+//
+// The iterator walks over a snapshot of the Stack, taken when All is called:
+// the Stack can safely be modified while iterating.
+func (s *Stack[T]) All() iter.Seq[T] {
+	return s.container.All()
+}
+
+// Backward returns an iterator over the Stack's items, from its bottom to its top.
+//
+// The iterator walks over a snapshot of the Stack, taken when Backward is called:
+// the Stack can safely be modified while iterating.
+func (s *Stack[T]) Backward() iter.Seq[T] {
+	return s.container.Backward()
+}
+
+// MarshalJSON encodes the Stack's items as a JSON array, from its top
+// to its bottom.
+func (s *Stack[T]) MarshalJSON() ([]byte, error) {
+	return s.container.MarshalJSON()
+}
+
+// UnmarshalJSON replaces the Stack's items with the ones of the
+// provided JSON array, from its top to its bottom.
+func (s *Stack[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	s.replace(items)
+
+	return nil
+}
+
+// MarshalBinary encodes the Stack's items, from its top to its bottom,
+// using gob.
+func (s *Stack[T]) MarshalBinary() ([]byte, error) {
+	return s.container.MarshalBinary()
+}
+
+// UnmarshalBinary replaces the Stack's items with the ones encoded
+// by MarshalBinary.
+func (s *Stack[T]) UnmarshalBinary(data []byte) error {
+	items, err := unmarshalGob[[]T](data)
+	if err != nil {
+		return err
+	}
+
+	s.replace(items)
+
+	return nil
+}
+
+// replace replaces the Stack's items with the provided ones, from its
+// top to its bottom.
+func (s *Stack[T]) replace(items []T) {
+	if s.container == nil {
+		s.container = NewDeque[T]()
+	}
+
+	s.container.replace(items)
+}
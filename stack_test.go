@@ -1,7 +1,10 @@
 package lane
 
 import (
+	"context"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -237,3 +240,145 @@ func BenchmarkStackHead(b *testing.B) {
 		stack.Head()
 	}
 }
+
+func TestStackPopWait(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		stack     *Stack[int]
+		push      []int
+		wantValue int
+		wantErr   error
+	}{
+		{
+			desc:      "PopWait on a filled Stack returns the head value",
+			stack:     NewStack([]int{42, 41, 40}...),
+			wantValue: 42,
+		},
+		{
+			desc:      "PopWait on an empty Stack returns the pushed value",
+			stack:     NewStack[int](),
+			push:      []int{42},
+			wantValue: 42,
+		},
+		{
+			desc:    "PopWait on an empty Stack returns the context error",
+			stack:   NewStack[int](),
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			go func() {
+				for _, item := range tC.push {
+					tC.stack.Push(item)
+				}
+			}()
+
+			gotValue, gotErr := tC.stack.PopWait(ctx)
+
+			assert.ErrorIs(t, gotErr, tC.wantErr)
+			assert.Equal(t, tC.wantValue, gotValue)
+		})
+	}
+}
+
+func TestStackAll(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc         string
+		stack        *Stack[int]
+		wantAll      []int
+		wantBackward []int
+	}{
+		{
+			desc:  "iterating over an empty Stack yields nothing",
+			stack: NewStack[int](),
+		},
+		{
+			desc:         "iterating over a filled Stack yields its items in popping order",
+			stack:        NewStack([]int{42, 41, 40}...),
+			wantAll:      []int{42, 41, 40},
+			wantBackward: []int{40, 41, 42},
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tC.wantAll, slices.Collect(tC.stack.All()))
+			assert.Equal(t, tC.wantBackward, slices.Collect(tC.stack.Backward()))
+		})
+	}
+}
+
+func TestStackBatchOperations(t *testing.T) {
+	t.Parallel()
+
+	stack := NewStack([]int{40}...)
+	stack.PushAll(41, 42, 43)
+
+	assert.Equal(t, []int{43, 42}, stack.PopN(2))
+	assert.Equal(t, []int{41, 40}, stack.PopN(5))
+	assert.Equal(t, []int{}, stack.PopN(1))
+}
+
+func TestStackEmpty(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		stack     *Stack[int]
+		wantEmpty bool
+	}{
+		{
+			desc:      "Empty on an empty Stack",
+			stack:     NewStack[int](),
+			wantEmpty: true,
+		},
+		{
+			desc:      "Empty on a filled Stack",
+			stack:     NewStack([]int{42}...),
+			wantEmpty: false,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tC.wantEmpty, tC.stack.Empty())
+		})
+	}
+}
+
+func TestStackClearCloneAndToSlice(t *testing.T) {
+	t.Parallel()
+
+	stack := NewStack([]int{42, 41, 40}...)
+	clone := stack.Clone()
+
+	assert.Equal(t, []int{42, 41, 40}, stack.ToSlice())
+
+	stack.Clear()
+	clone.Push(43)
+
+	assert.True(t, stack.Empty())
+	assert.Equal(t, []int{}, stack.ToSlice())
+	assert.Equal(t, []int{43, 42, 41, 40}, clone.ToSlice())
+}
@@ -0,0 +1,76 @@
+package lane
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// waitList is a FIFO list of goroutines parked on a container, waiting
+// for a condition on it to change.
+//
+// It plays the role of a sync.Cond which would support context cancellation:
+// every operation on a waitList expects the caller to hold the lock guarding
+// the container it belongs to.
+type waitList struct {
+	waiters List[chan struct{}]
+}
+
+// wait parks the calling goroutine until it is signaled, or until ctx is done.
+//
+// The caller must hold mu, which is released while waiting and acquired again
+// before wait returns. If ctx is done before the goroutine is signaled, wait
+// returns the context's error.
+func (w *waitList) wait(ctx context.Context, mu sync.Locker) error {
+	return w.waitTimeout(ctx, mu, nil)
+}
+
+// waitTimeout behaves like wait, but also stops waiting, without error, once
+// a value is received from the timeout channel. A nil timeout channel never
+// times out.
+func (w *waitList) waitTimeout(ctx context.Context, mu sync.Locker, timeout <-chan time.Time) error {
+	waiter := w.waiters.PushBack(make(chan struct{}))
+	mu.Unlock()
+
+	select {
+	case <-waiter.Value:
+		mu.Lock()
+		return nil
+	case <-timeout:
+		mu.Lock()
+		w.leave(waiter)
+
+		return nil
+	case <-ctx.Done():
+		mu.Lock()
+		w.leave(waiter)
+
+		return ctx.Err()
+	}
+}
+
+// signal wakes the goroutine which has been waiting the longest, if any.
+func (w *waitList) signal() {
+	if front := w.waiters.Front(); front != nil {
+		close(w.waiters.Remove(front))
+	}
+}
+
+// signalN wakes up to n goroutines, in the order they started waiting.
+func (w *waitList) signalN(n int) {
+	for ; n > 0 && w.waiters.Len() > 0; n-- {
+		w.signal()
+	}
+}
+
+// leave removes waiter from the list. If waiter was signaled concurrently
+// with its departure, the wake-up is handed over to the next waiting
+// goroutine, so that it does not get lost.
+func (w *waitList) leave(waiter *Element[chan struct{}]) {
+	if waiter.list == nil {
+		w.signal()
+		return
+	}
+
+	w.waiters.Remove(waiter)
+}
@@ -0,0 +1,96 @@
+package lane
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitListWait(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		signal  bool
+		wantErr error
+	}{
+		{
+			desc:    "wait returns once signaled",
+			signal:  true,
+			wantErr: nil,
+		},
+		{
+			desc:    "wait returns the context error once it is done",
+			signal:  false,
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tC := range testCases {
+		tC := tC
+
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu   sync.Mutex
+				list waitList
+			)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			if tC.signal {
+				go func() {
+					mu.Lock()
+					defer mu.Unlock()
+
+					for list.waiters.Len() == 0 {
+						mu.Unlock()
+						time.Sleep(time.Millisecond)
+						mu.Lock()
+					}
+
+					list.signal()
+				}()
+			}
+
+			mu.Lock()
+			gotErr := list.wait(ctx, &mu)
+			gotWaiters := list.waiters.Len()
+			mu.Unlock()
+
+			assert.ErrorIs(t, gotErr, tC.wantErr)
+			assert.Equal(t, uint(0), gotWaiters)
+		})
+	}
+}
+
+func TestWaitListLeave(t *testing.T) {
+	t.Parallel()
+
+	var list waitList
+
+	first := list.waiters.PushBack(make(chan struct{}))
+	second := list.waiters.PushBack(make(chan struct{}))
+
+	// The first waiter is signaled, but leaves as if its context
+	// was cancelled concurrently: the wake-up goes to the second one.
+	list.signal()
+	list.leave(first)
+
+	assert.Equal(t, uint(0), list.waiters.Len())
+	assert.True(t, isClosed(second.Value))
+}
+
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}